@@ -2,56 +2,447 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"os/signal"
 	"scanr/internal/cli"
 	"scanr/internal/config"
+	"scanr/internal/fs"
+	"scanr/internal/output"
+	"scanr/internal/review"
+	"scanr/internal/version"
 	"strings"
+	"syscall"
 )
 
+// command is a scanr subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, args []string) int
+}
+
+// commandList returns the subcommands in the order shown in the usage
+func commandList() []command {
+	return []command{
+		{"review", "Review code changes (the default command)", runReview},
+		{"stats", "Show which files would be reviewed", runStats},
+		{"diff-runs", "Compare two saved JSON reports", runDiffRuns},
+		{"commit", "Review a commit message", runCommit},
+		{"hooks", "Install or remove git hooks that run scanr", runHooks},
+		{"cache", "Show, clear or prune the cache", runCache},
+		{"init", "Scaffold the AI reviewer config (not available yet)", runInit},
+		{"languages", "List the supported languages", runLanguages},
+		{"version", "Print the scanr version", runVersion},
+	}
+}
+
 func main() {
-	ctx := context.Background()
+	ctx := interruptContext()
+
+	// Without a command name, the arguments are review flags and paths
+	args := os.Args[1:]
+	run := runReview
+	if len(args) > 0 {
+		for _, cmd := range commandList() {
+			if cmd.name == args[0] {
+				run, args = cmd.run, args[1:]
+				break
+			}
+		}
+	}
+
+	os.Exit(run(ctx, args))
+}
 
-	// Define CLI flag
-	langFlag := flag.String("lang", "", "Comma-separated language names to review (go,java,typescript,etc)")
-	stagedFlag := flag.Bool("staged", true, "Review only staged changes")
-	maxFilesFlag := flag.Int("max-files", 100, "Maximum number of files to review")
-	formatFlag := flag.String("format", "text", "Output format: text or json")
+// runReview parses the review flags and reviews the selected files
+func runReview(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("review", flag.ExitOnError)
+	langFlag := flags.String("lang", "", "Comma-separated language names to review (go,java,typescript,etc)")
+	stagedFlag := flags.Bool("staged", true, "Review only staged changes")
+	includeUntrackedFlag := flags.Bool("include-untracked", false, "Also review untracked files (with --staged=false)")
+	sinceFlag := flags.String("since", "", "Review files changed on HEAD since this git ref (e.g. main), instead of --staged")
+	branchFlag := flags.String("branch", "HEAD", "Branch to review in full when run in a bare repository")
+	stdinFlag := flags.Bool("stdin", false, "Review newline-separated file paths read from stdin instead of git or a scan")
+	maxFilesFlag := flags.Int("max-files", 100, "Maximum number of files to review")
+	maxFileSizeFlag := flags.String("max-file-size", "1m", "Skip files larger than this size in bytes (k, m and g suffixes allowed, e.g. 512k)")
+	maxLinesFlag := flags.Int("max-lines", fs.DefaultMaxLines, "Skip files with more lines than this")
+	workersFlag := flags.Int("workers", review.DefaultConfig().MaxWorkers, "Number of files reviewed concurrently (AI providers may rate limit high values)")
+	perFileTimeoutFlag := flags.Duration("per-file-timeout", 0, "Give up on reviewing a single file after this long (default: scaled by --timeout-per-line)")
+	timeoutPerLineFlag := flags.Duration("timeout-per-line", review.DefaultConfig().TimeoutPerLine, "Time allowed per line of a file, at least 10s per file")
+	queueSizeFlag := flags.Int("queue-size", review.DefaultConfig().MaxQueueSize, "Number of files queued for the workers")
+	formatFlag := flags.String("format", "text", "Output format: text, json, checkstyle, gitlab, html, markdown or csv (default gitlab when GITLAB_CI=true)")
+	csvSeparatorFlag := flags.String("csv-separator", ",", "Field separator for csv output (a single character, or tab)")
+	csvNoHeaderFlag := flags.Bool("csv-no-header", false, "Leave out the csv header row, for appending to an existing file")
+	outputFlag := flags.String("output", "", "Write the report to this file instead of stdout (parent directories are created, - for stdout)")
+	dryRunFlag := flags.Bool("dry-run", false, "List the files that would be reviewed without reviewing them")
+	streamFlag := flags.Bool("stream", false, "Print each file's results as soon as it is reviewed")
+	progressFlag := flags.Bool("progress", false, "Print review progress to stderr (default on only for text output)")
+	contextLinesFlag := flags.Int("context-lines", 2, "Source lines to show around each issue in text output (0 to disable)")
+	colorFlag := flags.String("color", "auto", "Color text output: auto (terminal only, honors NO_COLOR), always or never")
+	groupByFlag := flags.String("group-by", "file", "Group text and json results by file or directory (directory does not work with --stream)")
+	wrapFlag := flags.Int("wrap", -1, "Wrap text output at N columns (default: terminal width or 80, 0 disables)")
+	skipTestFilesFlag := flags.Bool("skip-test-files", false, "Skip test files such as *_test.go, test_*.py and *.spec.ts")
+	includeGeneratedFlag := flags.Bool("include-generated", false, "Review generated files (\"Code generated ... DO NOT EDIT\", @generated, minified) too")
+	showSuppressedFlag := flags.Bool("show-suppressed", false, "Report issues removed by filters separately")
+	showFixesFlag := flags.Bool("show-fixes", false, "Show suggested fixes as unified diffs in text output")
+	persistFailuresFlag := flags.Bool("persist-failures", true, "Record files that fail review under ~/.cache/scanr/deadletters as they fail")
+	dumpFailuresFlag := flags.String("dump-failures", "", "Write the files still failing after retries to this file as JSON lines")
+	excludePathsFlag := flags.String("exclude-paths", "", "Comma-separated globs of files to skip, relative to the repository root, or to the current directory for path arguments, --stdin and scans outside git (e.g. generated/,**/mocks/**,*.pb.go)")
+	ignoreFileFlag := flags.String("ignore-file", "", "Additional ignore file with gitignore-style patterns")
+	logLevelFlag := flags.String("log-level", "warn", "Log level: debug, info, warn or error")
+	logFormatFlag := flags.String("log-format", "text", "Log format: text or json (logs are written to stderr)")
+	focusFlag := flags.String("focus", "", "Comma-separated issue categories to report: "+strings.Join(review.Categories, ","))
+	categoryFlag := flags.String("category", "", "Alias of --focus")
+	minConfidenceFlag := flags.Float64("min-confidence", 0.0, "Hide issues with confidence below this value (0.0-1.0, 0 shows all)")
+	defaultConfidenceFlag := flags.Float64("default-confidence", 0.5, "Confidence assigned to issues reported without one (above 0, up to 1.0)")
 
-	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s [flags]\n", os.Args[0])
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s [review] [flags] [paths...]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "       %s <command> [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nCommands:\n")
+		for _, cmd := range commandList() {
+			fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.summary)
+		}
+		fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command.\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nPaths may be files, directories or glob patterns; when given they\n")
+		fmt.Fprintf(os.Stderr, "override --staged and only those files are reviewed.\n")
 		fmt.Fprintf(os.Stderr, "\nFlags:\n")
-		flag.PrintDefaults()
+		flags.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExit codes:\n")
 		fmt.Fprintf(os.Stderr, "	0 - No issues found\n")
 		fmt.Fprintf(os.Stderr, "	1 - Warnings found\n")
 		fmt.Fprintf(os.Stderr, "	2 - Critical issues found\n")
+		fmt.Fprintf(os.Stderr, "	130 - Interrupted; the results so far are printed\n")
 	}
 
-	flag.Parse()
+	flags.Parse(args)
+
+	// Without an explicit --format, use the report format of the CI system.
+	// Progress defaults to on for text output unless set explicitly.
+	format := strings.ToLower(*formatFlag)
+	if ciFormat := output.DetectCIFormat(); ciFormat != "" && !isFlagSet(flags, "format") {
+		format = ciFormat
+	}
+	progress := format == "text"
+	if isFlagSet(flags, "progress") {
+		progress = *progressFlag
+	}
+
+	maxFileSize, err := config.ParseByteSize(*maxFileSizeFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: max-file-size: %v\n", err)
+		return 2
+	}
 
 	// Create config
 	cfg := &config.Config{
-		Languages:  *langFlag,
-		StagedOnly: *stagedFlag,
-		MaxFiles:   *maxFilesFlag,
-		Format:     strings.ToLower(*formatFlag),
+		Languages:         *langFlag,
+		StagedOnly:        *stagedFlag,
+		MaxFiles:          *maxFilesFlag,
+		MaxFileSize:       maxFileSize,
+		MaxLines:          *maxLinesFlag,
+		Workers:           *workersFlag,
+		QueueSize:         *queueSizeFlag,
+		PerFileTimeout:    *perFileTimeoutFlag,
+		TimeoutPerLine:    *timeoutPerLineFlag,
+		Format:            format,
+		CSVSeparator:      *csvSeparatorFlag,
+		CSVNoHeader:       *csvNoHeaderFlag,
+		DefaultConfidence: *defaultConfidenceFlag,
+		MinConfidence:     *minConfidenceFlag,
+		IgnoreFile:        *ignoreFileFlag,
+		ExcludePaths:      parseList(*excludePathsFlag),
+		Stream:            *streamFlag,
+		ShowSuppressed:    *showSuppressedFlag,
+		ShowFixes:         *showFixesFlag,
+		PersistFailures:   *persistFailuresFlag,
+		DumpFailures:      *dumpFailuresFlag,
+		Progress:          progress,
+		Stdin:             *stdinFlag,
+		DryRun:            *dryRunFlag,
+		ContextLines:      *contextLinesFlag,
+		FocusAreas:        parseFocusAreas(*focusFlag + "," + *categoryFlag),
+		SkipTestFiles:     *skipTestFilesFlag,
+		IncludeGenerated:  *includeGeneratedFlag,
+		Wrap:              *wrapFlag,
+		Color:             strings.ToLower(*colorFlag),
+		GroupBy:           strings.ToLower(*groupByFlag),
+		Output:            *outputFlag,
+		Since:             *sinceFlag,
+		Branch:            *branchFlag,
+		IncludeUntracked:  *includeUntrackedFlag,
+		Paths:             flags.Args(),
 	}
 
 	// Validate config
 	if err := config.ValidateConfig(cfg); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(2)
+		return 2
+	}
+
+	// Configure structured logging before running the review
+	logger, err := newLogger(*logLevelFlag, *logFormatFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
 	}
+	slog.SetDefault(logger)
 
 	// Run the code review command
 	exitCode, err := cli.RunReview(ctx, cfg)
+	if ctx.Err() != nil {
+		// Whatever was reviewed before the interrupt has been printed
+		return output.ExitInterrupted
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		if exitCode == 0 {
 			exitCode = 2
 		}
 	}
-	os.Exit(exitCode)
+	return exitCode
+}
+
+// interruptContext returns a context cancelled by the first SIGINT or
+// SIGTERM, so a run can stop early and still print what it reviewed. A
+// second signal kills the process as usual.
+func interruptContext() context.Context {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+		fmt.Fprintln(os.Stderr, "\nInterrupted, finishing up (press Ctrl-C again to quit immediately)")
+	}()
+	return ctx
+}
+
+// isFlagSet reports whether a flag was given on the command line
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	set := false
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parseFocusAreas splits the --focus flag into lowercase categories
+func parseFocusAreas(value string) []string {
+	areas := parseList(value)
+	for i, area := range areas {
+		areas[i] = strings.ToLower(area)
+	}
+	return areas
+}
+
+// parseList splits a comma-separated flag value, dropping empty entries
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// newLogger builds a stderr logger for the given level and format
+func newLogger(level, format string) (*slog.Logger, error) {
+	var slogLevel slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		slogLevel = slog.LevelDebug
+	case "info":
+		slogLevel = slog.LevelInfo
+	case "warn", "warning":
+		slogLevel = slog.LevelWarn
+	case "error":
+		slogLevel = slog.LevelError
+	default:
+		return nil, fmt.Errorf("log-level must be one of debug, info, warn, error; got %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: slogLevel}
+
+	switch strings.ToLower(format) {
+	case "text":
+		return slog.New(slog.NewTextHandler(os.Stderr, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(os.Stderr, opts)), nil
+	default:
+		return nil, fmt.Errorf("log-format must be 'text' or 'json', got %q", format)
+	}
+}
+
+// runStats parses the stats subcommand flags and prints file statistics
+func runStats(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	langFlag := flags.String("lang", "", "Comma-separated language names to include (go,java,typescript,etc)")
+	maxFilesFlag := flags.Int("max-files", 0, "Maximum number of files to scan (0 for no limit)")
+	formatFlag := flags.String("format", "text", "Output format: text or json")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s stats [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nShow which files would be reviewed without calling any AI API.\n")
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+
+	cfg := &config.Config{
+		Languages: *langFlag,
+		MaxFiles:  *maxFilesFlag,
+		Format:    strings.ToLower(*formatFlag),
+	}
+
+	if cfg.Format != "text" && cfg.Format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: format must be 'text' or 'json', got %q\n", *formatFlag)
+		return 2
+	}
+
+	if err := cli.RunStats(ctx, cfg, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runDiffRuns compares two saved JSON reports
+func runDiffRuns(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("diff-runs", flag.ExitOnError)
+	formatFlag := flags.String("format", "text", "Output format: text or json")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s diff-runs [flags] <old.json> <new.json>\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nShow issues added, resolved and unchanged between two JSON reports.\n")
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	format := strings.ToLower(*formatFlag)
+	if format != "text" && format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: format must be 'text' or 'json', got %q\n", *formatFlag)
+		return 2
+	}
+
+	if err := cli.RunDiffRuns(flags.Arg(0), flags.Arg(1), format, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runCommit reviews a commit message
+func runCommit(ctx context.Context, args []string) int {
+	exitCode, err := cli.RunCommitReview(args, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	return exitCode
+}
+
+// runHooks installs or removes git hooks
+func runHooks(ctx context.Context, args []string) int {
+	if err := cli.RunHooksCmd(args); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runCache manages the cache directory
+func runCache(ctx context.Context, args []string) int {
+	if err := cli.RunCacheCmd(args, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runInit is reserved for scaffolding the AI reviewer config, which does not
+// exist yet
+func runInit(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("init", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s init\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nScaffold the AI reviewer config file. scanr only has a mock reviewer so far,\n")
+		fmt.Fprintf(os.Stderr, "so there is nothing to scaffold yet.\n")
+	}
+
+	flags.Parse(args)
+
+	fmt.Fprintf(os.Stderr, "Error: init is not available yet: scanr has no AI reviewer config to scaffold\n")
+	return 2
+}
+
+// runLanguages lists the supported languages
+func runLanguages(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("languages", flag.ExitOnError)
+	formatFlag := flags.String("format", "text", "Output format: text or json")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s languages [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nList the languages accepted by --lang and the files they match.\n")
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+
+	format := strings.ToLower(*formatFlag)
+	if format != "text" && format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: format must be 'text' or 'json', got %q\n", *formatFlag)
+		return 2
+	}
+
+	if err := cli.WriteLanguages(format, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 2
+	}
+	return 0
+}
+
+// runVersion prints the scanr version and build details
+func runVersion(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("version", flag.ExitOnError)
+	formatFlag := flags.String("format", "text", "Output format: text or json")
+
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s version [flags]\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	flags.Parse(args)
+
+	info := version.Get()
+	switch strings.ToLower(*formatFlag) {
+	case "text":
+		fmt.Fprintln(os.Stdout, info.String())
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(info); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 2
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: format must be 'text' or 'json', got %q\n", *formatFlag)
+		return 2
+	}
+	return 0
 }
@@ -2,7 +2,10 @@ module scanr
 
 go 1.25.5
 
-require github.com/fatih/color v1.18.0
+require (
+	github.com/fatih/color v1.18.0
+	golang.org/x/term v0.24.0
+)
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
@@ -0,0 +1,272 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheDirEnv overrides the cache location
+const CacheDirEnv = "SCANR_CACHE_DIR"
+
+// cacheMarker marks a directory as a scanr cache, in the CACHEDIR.TAG format
+// backup tools also recognise. clear and prune refuse to touch a directory
+// without it, so a mis-set SCANR_CACHE_DIR cannot wipe unrelated files.
+const (
+	cacheMarker          = "CACHEDIR.TAG"
+	cacheMarkerSignature = "Signature: 8a477f597d28d172789f06886806bc55"
+)
+
+// cacheSubdirs are the directories scanr writes cache entries to; nothing
+// else under the cache directory is counted or removed
+var cacheSubdirs = []string{"deadletters"}
+
+// CacheStats describes the contents of the cache directory
+type CacheStats struct {
+	Dir     string     `json:"dir"`
+	Entries int        `json:"entries"`
+	Size    int64      `json:"size"`
+	Oldest  *time.Time `json:"oldest,omitempty"`
+	Newest  *time.Time `json:"newest,omitempty"`
+}
+
+// CacheRemoval reports the entries deleted by clear or prune
+type CacheRemoval struct {
+	Dir     string `json:"dir"`
+	Removed int    `json:"removed"`
+	Freed   int64  `json:"freed"`
+}
+
+// CacheDir resolves the cache directory from SCANR_CACHE_DIR or ~/.cache/scanr
+func CacheDir() (string, error) {
+	if dir := os.Getenv(CacheDirEnv); dir != "" {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve home directory: %v", err)
+	}
+	return filepath.Join(home, ".cache", "scanr"), nil
+}
+
+// initCacheDir creates the cache directory and its marker
+func initCacheDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	path := filepath.Join(dir, cacheMarker)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	content := cacheMarkerSignature + "\n# This file marks the scanr cache directory.\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to mark cache directory: %v", err)
+	}
+	return nil
+}
+
+// checkCacheMarker returns an error unless dir is missing or marked as a scanr cache
+func checkCacheMarker(dir string) error {
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, cacheMarker))
+	if err != nil || !strings.HasPrefix(string(data), cacheMarkerSignature) {
+		return fmt.Errorf("refusing to remove files from %s: it has no %s marker, so it may not be a scanr cache (check %s)",
+			dir, cacheMarker, CacheDirEnv)
+	}
+	return nil
+}
+
+// RunCacheCmd runs the cache subcommands: show, clear and prune
+func RunCacheCmd(args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: scanr cache <show|clear|prune> [flags]")
+	}
+
+	flags := flag.NewFlagSet("cache "+args[0], flag.ContinueOnError)
+	formatFlag := flags.String("format", "text", "Output format: text or json")
+	var olderThanFlag *string
+	if args[0] == "prune" {
+		olderThanFlag = flags.String("older-than", "7d", "Remove entries older than this duration (e.g. 12h, 7d)")
+	}
+
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	format := strings.ToLower(*formatFlag)
+	if format != "text" && format != "json" {
+		return fmt.Errorf("format must be 'text' or 'json', got %q", *formatFlag)
+	}
+
+	dir, err := CacheDir()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "show":
+		stats, err := cacheStats(dir)
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return writeCacheJSON(stats, w)
+		}
+		writeCacheStatsText(stats, w)
+		return nil
+
+	case "clear":
+		removal, err := pruneCache(dir, time.Time{})
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return writeCacheJSON(removal, w)
+		}
+		fmt.Fprintf(w, "Removed %d cache entries (%s) from %s\n", removal.Removed, formatBytes(removal.Freed), dir)
+		return nil
+
+	case "prune":
+		age, err := parseAge(*olderThanFlag)
+		if err != nil {
+			return err
+		}
+		removal, err := pruneCache(dir, time.Now().Add(-age))
+		if err != nil {
+			return err
+		}
+		if format == "json" {
+			return writeCacheJSON(removal, w)
+		}
+		fmt.Fprintf(w, "Removed %d cache entries older than %s (%s) from %s\n",
+			removal.Removed, *olderThanFlag, formatBytes(removal.Freed), dir)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown cache command %q (expected show, clear or prune)", args[0])
+	}
+}
+
+// cacheStats walks the cache directory; a missing directory is an empty cache
+func cacheStats(dir string) (CacheStats, error) {
+	stats := CacheStats{Dir: dir}
+
+	err := walkCacheEntries(dir, func(path string, info fs.FileInfo) error {
+		stats.Entries++
+		stats.Size += info.Size()
+
+		modTime := info.ModTime()
+		if stats.Oldest == nil || modTime.Before(*stats.Oldest) {
+			stats.Oldest = &modTime
+		}
+		if stats.Newest == nil || modTime.After(*stats.Newest) {
+			stats.Newest = &modTime
+		}
+		return nil
+	})
+
+	return stats, err
+}
+
+// pruneCache removes entries last modified before cutoff; a zero cutoff removes everything
+func pruneCache(dir string, cutoff time.Time) (CacheRemoval, error) {
+	removal := CacheRemoval{Dir: dir}
+	if err := checkCacheMarker(dir); err != nil {
+		return removal, err
+	}
+
+	err := walkCacheEntries(dir, func(path string, info fs.FileInfo) error {
+		if !cutoff.IsZero() && !info.ModTime().Before(cutoff) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove cache entry %s: %v", path, err)
+		}
+		removal.Removed++
+		removal.Freed += info.Size()
+		return nil
+	})
+
+	return removal, err
+}
+
+// walkCacheEntries calls fn for every regular file in the cache subdirectories
+func walkCacheEntries(dir string, fn func(path string, info fs.FileInfo) error) error {
+	for _, subdir := range cacheSubdirs {
+		err := filepath.WalkDir(filepath.Join(dir, subdir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return fn(path, info)
+		})
+
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read cache directory %s: %v", dir, err)
+		}
+	}
+	return nil
+}
+
+// parseAge parses a Go duration, also accepting whole days such as "7d"
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	age, err := time.ParseDuration(value)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return age, nil
+}
+
+// writeCacheJSON writes v as indented JSON
+func writeCacheJSON(v interface{}, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// writeCacheStatsText writes the cache statistics as text
+func writeCacheStatsText(stats CacheStats, w io.Writer) {
+	fmt.Fprintf(w, "CACHE\n")
+	fmt.Fprintf(w, "%s\n", strings.Repeat("-", 40))
+	fmt.Fprintf(w, "Directory: %s\n", stats.Dir)
+	fmt.Fprintf(w, "Entries:   %d\n", stats.Entries)
+	fmt.Fprintf(w, "Size:      %s\n", formatBytes(stats.Size))
+	if stats.Oldest != nil {
+		fmt.Fprintf(w, "Oldest:    %s\n", stats.Oldest.Format(time.RFC1123))
+		fmt.Fprintf(w, "Newest:    %s\n", stats.Newest.Format(time.RFC1123))
+	}
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// createCacheEntry writes a cache file with the given age
+func createCacheEntry(t *testing.T, dir, name string, size int, age time.Duration) {
+	t.Helper()
+
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRunCacheCmd(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(CacheDirEnv, dir)
+	if err := initCacheDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	createCacheEntry(t, dir, "deadletters/fresh.jsonl", 100, time.Hour)
+	createCacheEntry(t, dir, "deadletters/stale.jsonl", 200, 10*24*time.Hour)
+	createCacheEntry(t, dir, "deadletters/old.jsonl", 300, 30*24*time.Hour)
+	// Files scanr did not write are neither counted nor removed
+	createCacheEntry(t, dir, "notes/keep.txt", 50, 30*24*time.Hour)
+
+	var buf bytes.Buffer
+	if err := RunCacheCmd([]string{"show", "--format", "json"}, &buf); err != nil {
+		t.Fatalf("show failed: %v", err)
+	}
+
+	var stats CacheStats
+	if err := json.Unmarshal(buf.Bytes(), &stats); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if stats.Entries != 3 || stats.Size != 600 {
+		t.Errorf("show = %d entries, %d bytes; want 3, 600", stats.Entries, stats.Size)
+	}
+	if stats.Oldest == nil || stats.Newest == nil || !stats.Oldest.Before(*stats.Newest) {
+		t.Errorf("unexpected timestamps: %v - %v", stats.Oldest, stats.Newest)
+	}
+
+	buf.Reset()
+	if err := RunCacheCmd([]string{"prune", "--older-than", "7d", "--format", "json"}, &buf); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	var removal CacheRemoval
+	if err := json.Unmarshal(buf.Bytes(), &removal); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if removal.Removed != 2 || removal.Freed != 500 {
+		t.Errorf("prune removed %d entries (%d bytes), want 2 (500)", removal.Removed, removal.Freed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "deadletters/fresh.jsonl")); err != nil {
+		t.Error("fresh entry should survive prune")
+	}
+
+	buf.Reset()
+	if err := RunCacheCmd([]string{"clear"}, &buf); err != nil {
+		t.Fatalf("clear failed: %v", err)
+	}
+	if stats, _ := cacheStats(dir); stats.Entries != 0 {
+		t.Errorf("%d entries left after clear", stats.Entries)
+	}
+	for _, name := range []string{"notes/keep.txt", cacheMarker} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s should survive clear: %v", name, err)
+		}
+	}
+}
+
+func TestRunCacheCmd_UnmarkedDir(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(CacheDirEnv, dir)
+	createCacheEntry(t, dir, "deadletters/run.jsonl", 100, 30*24*time.Hour)
+
+	// A directory scanr never set up, such as a mis-set $HOME, is left alone
+	var buf bytes.Buffer
+	for _, args := range [][]string{{"clear"}, {"prune", "--older-than", "1d"}} {
+		if err := RunCacheCmd(args, &buf); err == nil {
+			t.Errorf("%s: expected error for a directory without the cache marker", args[0])
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "deadletters/run.jsonl")); err != nil {
+		t.Errorf("entry in unmarked directory was removed: %v", err)
+	}
+}
+
+func TestRunCacheCmd_MissingDir(t *testing.T) {
+	t.Setenv(CacheDirEnv, filepath.Join(t.TempDir(), "missing"))
+
+	var buf bytes.Buffer
+	if err := RunCacheCmd([]string{"show"}, &buf); err != nil {
+		t.Fatalf("show on missing cache failed: %v", err)
+	}
+	if err := RunCacheCmd([]string{"frobnicate"}, &buf); err == nil {
+		t.Error("expected error for unknown command")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"xd", 0, true},
+		{"-1d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAge(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAge(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAge(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
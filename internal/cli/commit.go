@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"scanr/internal/git"
+	"scanr/internal/output"
+	"scanr/internal/review"
+	"scanr/pkg/reviewer"
+)
+
+// RunCommitReview reviews a commit message given with --message, read from
+// --file or taken from the repository's COMMIT_EDITMSG. With --install-hook it
+// installs the commit-msg hook instead.
+func RunCommitReview(args []string, w io.Writer) (int, error) {
+	flags := flag.NewFlagSet("commit", flag.ContinueOnError)
+	messageFlag := flags.String("message", "", "Commit message to review")
+	fileFlag := flags.String("file", "", "File containing the commit message (default: .git/COMMIT_EDITMSG)")
+	formatFlag := flags.String("format", "text", "Output format: text or json")
+	installHookFlag := flags.Bool("install-hook", false, "Install a commit-msg hook that runs this review")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0, nil
+		}
+		return 2, err
+	}
+
+	format := strings.ToLower(*formatFlag)
+	if format != "text" && format != "json" {
+		return 2, fmt.Errorf("format must be 'text' or 'json', got %q", *formatFlag)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return 2, fmt.Errorf("failed to get current directory: %v", err)
+	}
+
+	if *installHookFlag {
+		path, err := installCommitHook(cwd)
+		if err != nil {
+			return 2, err
+		}
+		fmt.Fprintf(w, "Installed commit-msg hook at %s\n", path)
+		return 0, nil
+	}
+
+	source := "commit message"
+	message := *messageFlag
+	if message == "" {
+		path := *fileFlag
+		if path == "" {
+			repo, err := git.DetectRepository(cwd)
+			if err != nil {
+				return 2, fmt.Errorf("no --message or --file given and not in a git repository: %v", err)
+			}
+			path = filepath.Join(repo.GitDir, "COMMIT_EDITMSG")
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return 2, fmt.Errorf("failed to read commit message: %v", err)
+		}
+		message = string(data)
+		source = path
+	}
+
+	issues := reviewer.NewCommitMessageReviewer().ReviewMessage(source, message)
+
+	if format == "json" {
+		if issues == nil {
+			issues = []review.Issue{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(issues); err != nil {
+			return 2, err
+		}
+	} else {
+		writeCommitIssuesText(issues, w)
+	}
+
+	result := &review.ReviewResult{TotalIssues: len(issues)}
+	for _, issue := range issues {
+		switch issue.Severity {
+		case review.SeverityCritical:
+			result.CriticalCount++
+		case review.SeverityHigh:
+			result.WarningCount++
+		default:
+			result.InfoCount++
+		}
+	}
+	return output.DetermineExitCode(result), nil
+}
+
+// installCommitHook writes the commit-msg hook into the repository containing
+// dir. It never touches a hook scanr did not write; 'scanr hooks install
+// --type commit-msg' offers to append to one instead.
+func installCommitHook(dir string) (string, error) {
+	return installHook(dir, "commit-msg", nil, nil)
+}
+
+// writeCommitIssuesText lists commit message issues one per line
+func writeCommitIssuesText(issues []review.Issue, w io.Writer) {
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "Commit message looks good\n")
+		return
+	}
+
+	fmt.Fprintf(w, "Commit message: %d issue(s)\n", len(issues))
+	for _, issue := range issues {
+		fmt.Fprintf(w, "  [%s] line %d: %s\n", strings.ToUpper(string(issue.Severity)), issue.Line, issue.Title)
+		fmt.Fprintf(w, "    %s\n", issue.Description)
+		for _, suggestion := range issue.Suggestions {
+			fmt.Fprintf(w, "    - %s\n", suggestion)
+		}
+	}
+}
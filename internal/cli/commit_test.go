@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scanr/internal/review"
+)
+
+func TestRunCommitReview_Message(t *testing.T) {
+	var buf bytes.Buffer
+	code, err := RunCommitReview([]string{"--message", "wip", "--format", "json"}, &buf)
+	if err != nil {
+		t.Fatalf("RunCommitReview failed: %v", err)
+	}
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1 for warnings", code)
+	}
+
+	var issues []review.Issue
+	if err := json.Unmarshal(buf.Bytes(), &issues); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+	if len(issues) == 0 {
+		t.Error("expected issues for a vague subject")
+	}
+}
+
+func TestRunCommitReview_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	if err := os.WriteFile(path, []byte("Add retry to the worker pool\n\nFixes #7\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	code, err := RunCommitReview([]string{"--file", path}, &buf)
+	if err != nil {
+		t.Fatalf("RunCommitReview failed: %v", err)
+	}
+	if code != 0 || !strings.Contains(buf.String(), "looks good") {
+		t.Errorf("exit code = %d, output:\n%s", code, buf.String())
+	}
+}
+
+func TestInstallCommitHook(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := installCommitHook(dir)
+	if err != nil {
+		t.Fatalf("installCommitHook failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("hook not written: %v", err)
+	}
+	if info.Mode()&0100 == 0 {
+		t.Error("hook is not executable")
+	}
+
+	// Reinstalling over our own hook is fine, replacing another is not
+	if _, err := installCommitHook(dir); err != nil {
+		t.Errorf("reinstall failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := installCommitHook(dir); err == nil {
+		t.Error("expected error when a foreign hook exists")
+	}
+}
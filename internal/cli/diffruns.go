@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"scanr/internal/output"
+	"scanr/internal/review"
+)
+
+// RunDiff classifies issues between two saved JSON reports
+type RunDiff struct {
+	Added     []output.JSONIssue `json:"added"`
+	Resolved  []output.JSONIssue `json:"resolved"`
+	Unchanged []output.JSONIssue `json:"unchanged"`
+}
+
+// RunDiffRuns loads two JSON reports and prints issues added, resolved and unchanged
+func RunDiffRuns(oldPath, newPath, format string, w io.Writer) error {
+	oldIssues, err := loadReportIssues(oldPath)
+	if err != nil {
+		return err
+	}
+
+	newIssues, err := loadReportIssues(newPath)
+	if err != nil {
+		return err
+	}
+
+	diff := diffIssues(oldIssues, newIssues)
+
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(diff)
+	}
+
+	writeRunDiffText(diff, w)
+	return nil
+}
+
+// loadReportIssues reads a JSON report and returns all of its issues
+func loadReportIssues(path string) ([]output.JSONIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %v", path, err)
+	}
+
+	var report output.JSONOutput
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %v", path, err)
+	}
+
+	// Reports are grouped by file or directory, or a flat issue list
+	issues := append([]output.JSONIssue{}, report.Issues...)
+	issues = appendFileIssues(issues, report.Results)
+	for _, directory := range report.Directories {
+		issues = appendFileIssues(issues, directory.Files)
+	}
+
+	return issues, nil
+}
+
+// appendFileIssues appends the issues of each file result, filling in the
+// file path issues leave out in grouped reports
+func appendFileIssues(issues []output.JSONIssue, results []output.JSONFileResult) []output.JSONIssue {
+	for _, fileResult := range results {
+		for _, issue := range fileResult.Issues {
+			if issue.Relative == "" {
+				issue.Relative = fileResult.File.Relative
+			}
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// diffIssues compares two issue sets by fingerprint
+func diffIssues(oldIssues, newIssues []output.JSONIssue) RunDiff {
+	oldSet := make(map[string]output.JSONIssue, len(oldIssues))
+	for _, issue := range oldIssues {
+		oldSet[issueFingerprint(issue)] = issue
+	}
+
+	newSet := make(map[string]bool, len(newIssues))
+	diff := RunDiff{
+		Added:     []output.JSONIssue{},
+		Resolved:  []output.JSONIssue{},
+		Unchanged: []output.JSONIssue{},
+	}
+
+	for _, issue := range newIssues {
+		fp := issueFingerprint(issue)
+		if newSet[fp] {
+			continue
+		}
+		newSet[fp] = true
+
+		if _, ok := oldSet[fp]; ok {
+			diff.Unchanged = append(diff.Unchanged, issue)
+		} else {
+			diff.Added = append(diff.Added, issue)
+		}
+	}
+
+	for fp, issue := range oldSet {
+		if !newSet[fp] {
+			diff.Resolved = append(diff.Resolved, issue)
+		}
+	}
+
+	sortDiffIssues(diff.Added)
+	sortDiffIssues(diff.Resolved)
+	sortDiffIssues(diff.Unchanged)
+
+	return diff
+}
+
+// issueFingerprint identifies an issue independently of the machine that produced the report
+func issueFingerprint(issue output.JSONIssue) string {
+	path := issue.Relative
+	if path == "" {
+		path = issue.FilePath
+	}
+
+	return review.IssueFingerprint(path, issue.Title, issue.Line)
+}
+
+// sortDiffIssues orders issues by file then line
+func sortDiffIssues(issues []output.JSONIssue) {
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Relative == issues[j].Relative {
+			if issues[i].Line == issues[j].Line {
+				return issues[i].Title < issues[j].Title
+			}
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Relative < issues[j].Relative
+	})
+}
+
+// writeRunDiffText writes the diff as a human-readable list
+func writeRunDiffText(diff RunDiff, w io.Writer) {
+	fmt.Fprintf(w, "RUN COMPARISON\n")
+	fmt.Fprintf(w, "%s\n", strings.Repeat("-", 40))
+	fmt.Fprintf(w, "Added:     %d\n", len(diff.Added))
+	fmt.Fprintf(w, "Resolved:  %d\n", len(diff.Resolved))
+	fmt.Fprintf(w, "Unchanged: %d\n", len(diff.Unchanged))
+
+	writeRunDiffSection("ADDED", "+", diff.Added, w)
+	writeRunDiffSection("RESOLVED", "-", diff.Resolved, w)
+}
+
+// writeRunDiffSection writes one group of issues
+func writeRunDiffSection(title, marker string, issues []output.JSONIssue, w io.Writer) {
+	if len(issues) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s\n", title)
+	for _, issue := range issues {
+		path := issue.Relative
+		if path == "" {
+			path = issue.FilePath
+		}
+		fmt.Fprintf(w, "  %s [%s] %s:%d %s\n",
+			marker, strings.ToUpper(issue.Severity), path, issue.Line, issue.Title)
+	}
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scanr/internal/output"
+)
+
+// writeReport saves a JSON report to a temp file and returns its path
+func writeReport(t *testing.T, dir, name string, report output.JSONOutput) string {
+	t.Helper()
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunDiffRuns_Classification(t *testing.T) {
+	dir := t.TempDir()
+
+	oldReport := output.JSONOutput{
+		Results: []output.JSONFileResult{
+			{
+				File: output.JSONFileInfo{Relative: "main.go"},
+				Issues: []output.JSONIssue{
+					{Title: "Unhandled error", Line: 10, Severity: "critical"},
+					{Title: "Magic number", Line: 20, Severity: "info"},
+				},
+			},
+		},
+	}
+
+	newReport := output.JSONOutput{
+		Issues: []output.JSONIssue{
+			{Relative: "main.go", Title: "Unhandled error", Line: 10, Severity: "critical"},
+			{Relative: "util.go", Title: "Long function", Line: 5, Severity: "warning"},
+		},
+	}
+
+	oldPath := writeReport(t, dir, "run1.json", oldReport)
+	newPath := writeReport(t, dir, "run2.json", newReport)
+
+	var buf bytes.Buffer
+	if err := RunDiffRuns(oldPath, newPath, "json", &buf); err != nil {
+		t.Fatalf("RunDiffRuns failed: %v", err)
+	}
+
+	var diff RunDiff
+	if err := json.Unmarshal(buf.Bytes(), &diff); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].Title != "Long function" {
+		t.Errorf("added = %+v, want [Long function]", diff.Added)
+	}
+	if len(diff.Resolved) != 1 || diff.Resolved[0].Title != "Magic number" {
+		t.Errorf("resolved = %+v, want [Magic number]", diff.Resolved)
+	}
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].Title != "Unhandled error" {
+		t.Errorf("unchanged = %+v, want [Unhandled error]", diff.Unchanged)
+	}
+
+	buf.Reset()
+	if err := RunDiffRuns(oldPath, newPath, "text", &buf); err != nil {
+		t.Fatalf("RunDiffRuns text failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "+ [WARNING] util.go:5 Long function") {
+		t.Errorf("text output missing added issue:\n%s", buf.String())
+	}
+}
+
+func TestRunDiffRuns_DirectoryGrouped(t *testing.T) {
+	dir := t.TempDir()
+
+	oldReport := output.JSONOutput{
+		Directories: []output.JSONDirectoryResult{
+			{
+				Directory: "pkg",
+				Files: []output.JSONFileResult{
+					{
+						File:   output.JSONFileInfo{Relative: "pkg/util.go"},
+						Issues: []output.JSONIssue{{Title: "Magic number", Line: 20, Severity: "info"}},
+					},
+				},
+			},
+		},
+	}
+	newReport := output.JSONOutput{
+		Issues: []output.JSONIssue{
+			{Relative: "pkg/util.go", Title: "Magic number", Line: 20, Severity: "info"},
+			{Relative: "pkg/util.go", Title: "Long function", Line: 5, Severity: "warning"},
+		},
+	}
+
+	oldPath := writeReport(t, dir, "run1.json", oldReport)
+	newPath := writeReport(t, dir, "run2.json", newReport)
+
+	var buf bytes.Buffer
+	if err := RunDiffRuns(oldPath, newPath, "json", &buf); err != nil {
+		t.Fatalf("RunDiffRuns failed: %v", err)
+	}
+
+	var diff RunDiff
+	if err := json.Unmarshal(buf.Bytes(), &diff); err != nil {
+		t.Fatalf("invalid JSON output: %v", err)
+	}
+
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].Title != "Magic number" {
+		t.Errorf("unchanged = %+v, want [Magic number]", diff.Unchanged)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].Title != "Long function" {
+		t.Errorf("added = %+v, want [Long function]", diff.Added)
+	}
+	if len(diff.Resolved) != 0 {
+		t.Errorf("resolved = %+v, want none", diff.Resolved)
+	}
+}
+
+func TestRunDiffRuns_MissingFile(t *testing.T) {
+	var buf bytes.Buffer
+	if err := RunDiffRuns("/nonexistent/run1.json", "/nonexistent/run2.json", "text", &buf); err == nil {
+		t.Error("expected error for missing report")
+	}
+}
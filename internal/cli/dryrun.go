@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"scanr/internal/fs"
+)
+
+// DryRunReport lists the files a review would cover
+type DryRunReport struct {
+	Files           []DryRunFile `json:"files"`
+	EstimatedTokens int64        `json:"estimated_tokens"`
+}
+
+// DryRunFile describes one file that would be reviewed
+type DryRunFile struct {
+	Path     string `json:"path"`
+	Language string `json:"language"`
+	Size     int64  `json:"size"`
+	Lines    int    `json:"lines"`
+}
+
+// buildDryRunReport converts the selected files into a report
+func buildDryRunReport(files []fs.FileInfo) DryRunReport {
+	report := DryRunReport{Files: make([]DryRunFile, 0, len(files))}
+
+	var totalSize int64
+	for _, file := range files {
+		path := file.Relative
+		if path == "" {
+			path = file.Path
+		}
+
+		report.Files = append(report.Files, DryRunFile{
+			Path:     path,
+			Language: file.Languages,
+			Size:     file.Size,
+			Lines:    file.Lines,
+		})
+		totalSize += file.Size
+	}
+	report.EstimatedTokens = totalSize / charsPerToken
+
+	return report
+}
+
+// writeDryRun prints the files that would be reviewed
+func writeDryRun(files []fs.FileInfo, format string, w io.Writer) error {
+	report := buildDryRunReport(files)
+
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	fmt.Fprintf(w, "DRY RUN - %d file(s) would be reviewed\n", len(report.Files))
+	fmt.Fprintf(w, "%s\n", strings.Repeat("-", 40))
+
+	if len(report.Files) > 0 {
+		fmt.Fprintf(w, "%-50s %-12s %10s %8s\n", "FILE", "LANGUAGE", "SIZE", "LINES")
+		for _, file := range report.Files {
+			fmt.Fprintf(w, "%-50s %-12s %10s %8d\n",
+				file.Path, file.Language, formatBytes(file.Size), file.Lines)
+		}
+	}
+
+	fmt.Fprintf(w, "\nEstimated prompt tokens: ~%d (%d chars per token)\n",
+		report.EstimatedTokens, charsPerToken)
+	return nil
+}
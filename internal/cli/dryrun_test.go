@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"scanr/internal/fs"
+)
+
+func TestWriteDryRun(t *testing.T) {
+	files := []fs.FileInfo{
+		{Path: "/p/main.go", Relative: "main.go", Languages: "go", Size: 400, Lines: 20},
+		{Path: "/p/app.py", Relative: "app.py", Languages: "python", Size: 200, Lines: 10},
+	}
+
+	var buf bytes.Buffer
+	if err := writeDryRun(files, "json", &buf); err != nil {
+		t.Fatalf("writeDryRun failed: %v", err)
+	}
+
+	var report DryRunReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(report.Files) != 2 || report.Files[0].Path != "main.go" || report.Files[1].Language != "python" {
+		t.Errorf("unexpected files: %+v", report.Files)
+	}
+	if report.EstimatedTokens != 150 {
+		t.Errorf("EstimatedTokens = %d, want 150", report.EstimatedTokens)
+	}
+
+	buf.Reset()
+	if err := writeDryRun(files, "text", &buf); err != nil {
+		t.Fatalf("writeDryRun failed: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "2 file(s) would be reviewed") || !strings.Contains(output, "app.py") {
+		t.Errorf("unexpected text output:\n%s", output)
+	}
+}
+
+func TestWriteDryRun_NoFiles(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeDryRun(nil, "json", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"files": []`) {
+		t.Errorf("expected empty files array, got %s", buf.String())
+	}
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"scanr/internal/config"
+	"scanr/internal/fs"
+)
+
+// fileFilter applies the checks the scanner makes while walking a tree to
+// files named by git, stdin or path arguments, so a file is reviewed or
+// skipped the same way however it was selected
+type fileFilter struct {
+	languages []string
+	cfg       *config.Config
+	ignore    *fs.IgnoreMatcher
+	limits    fileLimits
+}
+
+// newFileFilter loads the scanr ignore rules under root
+func newFileFilter(root string, languages []string, cfg *config.Config) (*fileFilter, error) {
+	scanner, err := fs.NewScanner(scannerConfig(root, languages, cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scanner: %v", err)
+	}
+	ignore, err := scanner.ScanrIgnoreMatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ignore rules: %v", err)
+	}
+
+	return &fileFilter{
+		languages: languages,
+		cfg:       cfg,
+		ignore:    ignore,
+		limits:    newFileLimits(cfg),
+	}, nil
+}
+
+// filter builds the FileInfo for the file at the absolute path, reported as
+// relPath, or returns why the file is skipped
+func (f *fileFilter) filter(path, relPath string) (fs.FileInfo, string) {
+	language := fs.DetectLanguage(path, f.languages)
+	if language == "" {
+		return fs.FileInfo{}, "unsupported language"
+	}
+
+	if f.cfg.SkipTestFiles && fs.IsTestFile(language, path) {
+		return fs.FileInfo{}, "test file"
+	}
+
+	// Git already applies .gitignore; scanr's own ignore rules still apply
+	if f.ignore.Ignored(path) {
+		return fs.FileInfo{}, "ignored"
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return fs.FileInfo{}, "missing"
+	}
+
+	if info.Size() > f.limits.maxFileSize {
+		return fs.FileInfo{}, "over size limit"
+	}
+
+	if fs.IsBinaryFile(path) {
+		return fs.FileInfo{}, "binary"
+	}
+
+	if !f.cfg.IncludeGenerated && fs.IsGeneratedFile(path) {
+		return fs.FileInfo{}, "generated"
+	}
+
+	lines, err := countFileLines(path, f.limits.maxLines)
+	if err != nil {
+		return fs.FileInfo{}, "unreadable"
+	}
+	if lines > f.limits.maxLines {
+		return fs.FileInfo{}, "over line limit"
+	}
+
+	return fs.FileInfo{
+		Path:      path,
+		Size:      info.Size(),
+		Lines:     lines,
+		Languages: language,
+		Relative:  relPath,
+	}, ""
+}
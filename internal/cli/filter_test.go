@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scanr/internal/config"
+	"scanr/internal/fs"
+	"scanr/internal/git"
+)
+
+func TestFileFilter_SameForEveryInput(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"app.go":           "package app\n",
+		"app_test.go":      "package app\n",
+		"legacy/old.go":    "package legacy\n",
+		"skip/excluded.go": "package skip\n",
+		"blob.go":          "package app\x00\n",
+		"api.pb.go":        "// Code generated by protoc-gen-go. DO NOT EDIT.\npackage app\n",
+		".scanrignore":     "legacy/\n",
+	}
+	var names []string
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if name != ".scanrignore" {
+			names = append(names, name)
+		}
+	}
+
+	cfg := &config.Config{SkipTestFiles: true, ExcludePaths: []string{"skip/*.go"}}
+	languages := []string{"go"}
+
+	var changes []git.FileChange
+	for _, name := range names {
+		changes = append(changes, git.FileChange{Path: name, ChangeType: git.ChangeModified})
+	}
+	fromGit, err := filterAndConvertChanges(&git.Repository{Path: dir}, changes, languages, cfg)
+	if err != nil {
+		t.Fatalf("filterAndConvertChanges failed: %v", err)
+	}
+
+	fromStdin, err := readFileList(strings.NewReader(strings.Join(names, "\n")), dir, languages, cfg)
+	if err != nil {
+		t.Fatalf("readFileList failed: %v", err)
+	}
+
+	var paths []string
+	for _, name := range names {
+		paths = append(paths, filepath.Join(dir, name))
+	}
+	fromPaths, err := resolvePathArgs(context.Background(), dir, paths, languages, cfg)
+	if err != nil {
+		t.Fatalf("resolvePathArgs failed: %v", err)
+	}
+
+	// A directory argument is walked by the scanner
+	fromScan, err := resolvePathArgs(context.Background(), dir, []string{dir}, languages, cfg)
+	if err != nil {
+		t.Fatalf("resolvePathArgs failed: %v", err)
+	}
+
+	for input, got := range map[string][]string{
+		"git":   relativePaths(fromGit),
+		"stdin": relativePaths(fromStdin),
+		"paths": relativePaths(fromPaths),
+		"scan":  relativePaths(fromScan),
+	} {
+		if strings.Join(got, ",") != "app.go" {
+			t.Errorf("%s: got %v, want only app.go", input, got)
+		}
+	}
+}
+
+func relativePaths(files []fs.FileInfo) []string {
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, filepath.ToSlash(file.Relative))
+	}
+	return paths
+}
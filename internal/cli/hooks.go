@@ -0,0 +1,241 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"scanr/internal/git"
+)
+
+const (
+	// hookMarker starts the part of a hook written by scanr
+	hookMarker = "Installed by scanr"
+	// hookEndMarker ends it, so the check can be removed from a shared hook
+	hookEndMarker = "# End of scanr hook"
+	hookShebang   = "#!/bin/sh\n"
+)
+
+// hookPurposes lists the supported hook types and what the installed check does
+var hookPurposes = map[string]string{
+	"pre-commit": "review staged changes before committing",
+	"pre-push":   "review the commits being pushed",
+	"commit-msg": "review the commit message before committing",
+}
+
+// RunHooksCmd runs the hooks subcommands: install and uninstall
+func RunHooksCmd(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: scanr hooks <install|uninstall> [--type pre-push|pre-commit|commit-msg]")
+	}
+
+	flags := flag.NewFlagSet("hooks "+args[0], flag.ContinueOnError)
+	typeFlag := flags.String("type", "pre-push", "Hook to manage: pre-push, pre-commit or commit-msg")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	switch args[0] {
+	case "install":
+		return RunHookInstall(*typeFlag)
+	case "uninstall":
+		return RunHookUninstall(*typeFlag)
+	default:
+		return fmt.Errorf("unknown hooks command %q (expected install or uninstall)", args[0])
+	}
+}
+
+// RunHookInstall installs a git hook of the given type that runs scanr. When
+// the repository already has such a hook, it asks before appending to it.
+func RunHookInstall(hookType string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %v", err)
+	}
+
+	path, err := installHook(cwd, hookType, os.Stdin, os.Stdout)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Installed %s hook at %s\n", hookType, path)
+	return nil
+}
+
+// RunHookUninstall removes scanr from a git hook, deleting the hook if
+// nothing else is left in it
+func RunHookUninstall(hookType string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %v", err)
+	}
+
+	path, err := uninstallHook(cwd, hookType)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Removed scanr from %s\n", path)
+	return nil
+}
+
+// hookPath returns the path of a hook in the repository containing dir
+func hookPath(dir, hookType string) (string, error) {
+	if _, ok := hookPurposes[hookType]; !ok {
+		return "", fmt.Errorf("unsupported hook type %q (expected pre-push, pre-commit or commit-msg)", hookType)
+	}
+
+	repo, err := git.DetectRepository(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to find git repository: %v", err)
+	}
+	return filepath.Join(repo.GitDir, "hooks", hookType), nil
+}
+
+// installHook writes the scanr check into a hook. Our own check is replaced;
+// a hook written by something else is only appended to when the user agrees.
+// A nil in never agrees.
+func installHook(dir, hookType string, in io.Reader, w io.Writer) (string, error) {
+	path, err := hookPath(dir, hookType)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create hooks directory: %v", err)
+	}
+
+	block := hookBlock(hookType)
+	content := hookShebang + block
+
+	existing, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return "", fmt.Errorf("failed to read %s: %v", path, err)
+	default:
+		rest := string(existing)
+		if strings.Contains(rest, hookMarker) {
+			rest = removeHookBlock(rest)
+		} else if in == nil || !confirm(in, w, fmt.Sprintf("%s already exists. Append the scanr check to it?", path)) {
+			return "", fmt.Errorf("%s already exists; left it unchanged", path)
+		}
+
+		if !isEmptyHook(rest) {
+			content = strings.TrimRight(rest, "\n") + "\n\n" + block
+		}
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		return "", fmt.Errorf("failed to write %s hook: %v", hookType, err)
+	}
+	return path, nil
+}
+
+// uninstallHook removes the scanr check from a hook
+func uninstallHook(dir, hookType string) (string, error) {
+	path, err := hookPath(dir, hookType)
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(existing), hookMarker) {
+		return "", fmt.Errorf("no scanr %s hook installed", hookType)
+	}
+
+	rest := removeHookBlock(string(existing))
+	if isEmptyHook(rest) {
+		if err := os.Remove(path); err != nil {
+			return "", fmt.Errorf("failed to remove %s: %v", path, err)
+		}
+		return path, nil
+	}
+
+	if err := os.WriteFile(path, []byte(rest), 0755); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return path, nil
+}
+
+// hookBlock returns the shell snippet that runs scanr from a hook. Only
+// critical issues (exit code 2) fail the hook; warnings are printed.
+func hookBlock(hookType string) string {
+	var command string
+	switch hookType {
+	case "commit-msg":
+		command = `scanr commit --file "$1"
+[ $? -lt 2 ] || exit 1
+`
+	case "pre-push":
+		// Nothing is staged at push time, so review what the branch adds on top
+		// of its upstream, or of the remote's default branch when it has none
+		command = `scanr_langs=$(git config scanr.languages)
+scanr_base=$(git rev-parse --abbrev-ref '@{upstream}' 2>/dev/null || git symbolic-ref -q --short refs/remotes/origin/HEAD)
+if [ -z "$scanr_langs" ]; then
+	echo "scanr: skipped; set the languages to review with 'git config scanr.languages go,python'" >&2
+elif [ -z "$scanr_base" ]; then
+	echo "scanr: skipped; the branch has no upstream to compare against" >&2
+else
+	scanr --since="$scanr_base" --lang="$scanr_langs" --progress=false </dev/null
+	[ $? -lt 2 ] || exit 1
+fi
+`
+	default:
+		// Hooks have no terminal to prompt for languages, so they come from git config
+		command = `scanr_langs=$(git config scanr.languages)
+if [ -z "$scanr_langs" ]; then
+	echo "scanr: skipped; set the languages to review with 'git config scanr.languages go,python'" >&2
+else
+	scanr --staged --lang="$scanr_langs" --progress=false </dev/null
+	[ $? -lt 2 ] || exit 1
+fi
+`
+	}
+
+	return fmt.Sprintf("# %s: %s\n%s%s\n", hookMarker, hookPurposes[hookType], command, hookEndMarker)
+}
+
+// removeHookBlock cuts the scanr check out of a hook. Hooks written before
+// the end marker existed are scanr's alone, so nothing is left of them.
+func removeHookBlock(content string) string {
+	start := strings.Index(content, "# "+hookMarker)
+	if start < 0 {
+		return content
+	}
+
+	end := strings.Index(content[start:], hookEndMarker)
+	if end < 0 {
+		return ""
+	}
+	end += start + len(hookEndMarker)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	return strings.TrimRight(content[:start], "\n") + "\n" + content[end:]
+}
+
+// isEmptyHook reports whether a hook has nothing but a shebang and blank lines
+func isEmptyHook(content string) bool {
+	content = strings.TrimSpace(content)
+	return content == "" || content == strings.TrimSpace(hookShebang)
+}
+
+// confirm asks a yes/no question, defaulting to no
+func confirm(in io.Reader, w io.Writer, question string) bool {
+	fmt.Fprintf(w, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newHookRepo creates a directory that looks like a git repository
+func newHookRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestInstallHook_PrePush(t *testing.T) {
+	dir := newHookRepo(t)
+
+	path, err := installHook(dir, "pre-push", nil, nil)
+	if err != nil {
+		t.Fatalf("installHook failed: %v", err)
+	}
+	if path != filepath.Join(dir, ".git", "hooks", "pre-push") {
+		t.Errorf("path = %s", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := string(data)
+	if !strings.HasPrefix(script, hookShebang) || !strings.Contains(script, "git config scanr.languages") ||
+		!strings.Contains(script, "@{upstream}") || !strings.Contains(script, `scanr --since="$scanr_base"`) ||
+		strings.Contains(script, "--staged") {
+		t.Errorf("unexpected hook script:\n%s", script)
+	}
+
+	// Reinstalling replaces our check instead of duplicating it
+	if _, err := installHook(dir, "pre-push", nil, nil); err != nil {
+		t.Fatalf("reinstall failed: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if count := strings.Count(string(data), hookMarker); count != 1 {
+		t.Errorf("hook contains %d scanr checks after reinstall, want 1", count)
+	}
+}
+
+func TestInstallHook_ExistingHook(t *testing.T) {
+	dir := newHookRepo(t)
+	path := filepath.Join(dir, ".git", "hooks", "pre-commit")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	existing := "#!/bin/sh\nmake lint\n"
+	if err := os.WriteFile(path, []byte(existing), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Declining leaves the hook alone
+	var prompt bytes.Buffer
+	if _, err := installHook(dir, "pre-commit", strings.NewReader("n\n"), &prompt); err == nil {
+		t.Error("expected error when append is declined")
+	}
+	if !strings.Contains(prompt.String(), "Append") {
+		t.Errorf("expected an append prompt, got %q", prompt.String())
+	}
+	if data, _ := os.ReadFile(path); string(data) != existing {
+		t.Errorf("hook changed after declining:\n%s", data)
+	}
+
+	// Accepting appends the check after the existing commands
+	if _, err := installHook(dir, "pre-commit", strings.NewReader("y\n"), &prompt); err != nil {
+		t.Fatalf("installHook failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), existing) || !strings.Contains(string(data), hookMarker) ||
+		!strings.Contains(string(data), "scanr --staged") {
+		t.Errorf("check not appended:\n%s", data)
+	}
+
+	// Uninstalling restores the original hook
+	if _, err := uninstallHook(dir, "pre-commit"); err != nil {
+		t.Fatalf("uninstallHook failed: %v", err)
+	}
+	data, _ = os.ReadFile(path)
+	if string(data) != existing {
+		t.Errorf("hook after uninstall = %q, want %q", data, existing)
+	}
+}
+
+func TestUninstallHook(t *testing.T) {
+	dir := newHookRepo(t)
+
+	if _, err := uninstallHook(dir, "pre-push"); err == nil {
+		t.Error("expected error when no hook is installed")
+	}
+
+	path, err := installHook(dir, "pre-push", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := uninstallHook(dir, "pre-push"); err != nil {
+		t.Fatalf("uninstallHook failed: %v", err)
+	}
+
+	// A hook that only ran scanr is removed entirely
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("hook still exists after uninstall: %v", err)
+	}
+}
+
+func TestInstallHook_InvalidType(t *testing.T) {
+	if _, err := installHook(newHookRepo(t), "post-merge", nil, nil); err == nil {
+		t.Error("expected error for unsupported hook type")
+	}
+}
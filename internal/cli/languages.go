@@ -2,21 +2,18 @@ package cli
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+
+	"scanr/internal/languages"
 )
 
-var SupportedLanguages = map[string][]string{
-	"go":         {".go"},
-	"java":       {".java"},
-	"typescript": {".ts", ".tsx"},
-	"javascript": {".js", ".jsx"},
-	"python":     {".py"},
-	"csharp":     {".cs"},
-	"dotnet":     {".cs", ".vb", ".fs"},
-}
+// SupportedLanguages maps language keys to their file extensions
+var SupportedLanguages = languages.Extensions()
 
 type LanguageDisplay struct {
 	ID   int
@@ -24,14 +21,16 @@ type LanguageDisplay struct {
 	key  string
 }
 
-var LanguageList = []LanguageDisplay{
-	{1, "Go", "go"},
-	{2, "Java", "java"},
-	{3, "TypeScript", "typescript"},
-	{4, "JavaScript", "javascript"},
-	{5, "Python", "python"},
-	{6, "C#", "csharp"},
-	{7, ".NET", "dotnet"},
+// LanguageList numbers the supported languages for the interactive prompt
+var LanguageList = buildLanguageList()
+
+// buildLanguageList numbers languages from 1 in their declared order
+func buildLanguageList() []LanguageDisplay {
+	list := make([]LanguageDisplay, len(languages.All))
+	for i, lang := range languages.All {
+		list[i] = LanguageDisplay{ID: i + 1, Name: lang.Name, key: lang.Key}
+	}
+	return list
 }
 
 // ParseLanguages processes the --lang flag or prompts interactively
@@ -114,10 +113,7 @@ func promptForLanguage() ([]string, error) {
 	return parseLanguageFlag(input)
 }
 
-/**
-* Removes deduplicate strings from slice
- */
-
+// deduplicate removes duplicate strings, keeping the first occurrence of each
 func deduplicate(slice []string) []string {
 	seen := make(map[string]bool)
 	result := []string{}
@@ -142,3 +138,41 @@ func getLanguageByNumber(num int) (string, error) {
 	}
 	return "", fmt.Errorf("language number %d not found", num)
 }
+
+// languageEntry describes a supported language in 'scanr languages' output
+type languageEntry struct {
+	ID         int      `json:"id"`
+	Key        string   `json:"key"`
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+	Filenames  []string `json:"filenames,omitempty"`
+}
+
+// WriteLanguages lists the supported languages as text or json. The numbers
+// are those accepted by the interactive prompt.
+func WriteLanguages(format string, w io.Writer) error {
+	entries := make([]languageEntry, len(languages.All))
+	for i, lang := range languages.All {
+		entries[i] = languageEntry{
+			ID:         i + 1,
+			Key:        lang.Key,
+			Name:       lang.Name,
+			Extensions: lang.Extensions,
+			Filenames:  lang.Filenames,
+		}
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	}
+
+	for _, entry := range entries {
+		files := strings.Join(append(append([]string{}, entry.Filenames...), entry.Extensions...), " ")
+		if _, err := fmt.Fprintf(w, "%3d  %-12s %-16s %s\n", entry.ID, entry.Key, entry.Name, files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,15 @@
 package cli
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"scanr/internal/fs"
+)
 
 func TestParseLanguageFlag(t *testing.T) {
 	tests := []struct {
@@ -45,6 +54,18 @@ func TestParseLanguageFlag(t *testing.T) {
 			expected: []string{"go", "java", "typescript"},
 			wantErr:  false,
 		},
+		{
+			name:     "ruby and rust by name",
+			input:    "ruby,Rust",
+			expected: []string{"ruby", "rust"},
+			wantErr:  false,
+		},
+		{
+			name:     "ruby and rust by number",
+			input:    "8,9",
+			expected: []string{"ruby", "rust"},
+			wantErr:  false,
+		},
 		{
 			name:     "case insensitive names",
 			input:    "Go,PYTHON,TypeScript",
@@ -132,13 +153,19 @@ func TestGetLanguageByNumber(t *testing.T) {
 		{5, "python", false},
 		{6, "csharp", false},
 		{7, "dotnet", false},
+		{8, "ruby", false},
+		{9, "rust", false},
+		{10, "dockerfile", false},
+		{11, "compose", false},
+		{12, "kubernetes", false},
+		{13, "shell", false},
 		{0, "", true},
-		{8, "", true},
+		{14, "", true},
 		{-1, "", true},
 	}
 
 	for _, tt := range tests {
-		t.Run(string(rune(tt.number+'0')), func(t *testing.T) {
+		t.Run(strconv.Itoa(tt.number), func(t *testing.T) {
 			got, err := getLanguageByNumber(tt.number)
 
 			if tt.wantErr {
@@ -186,6 +213,16 @@ func TestDeduplicate(t *testing.T) {
 			input:    []string{},
 			expected: []string{},
 		},
+		{
+			name:     "preserves first-seen order",
+			input:    []string{"go", "python", "go"},
+			expected: []string{"go", "python"},
+		},
+		{
+			name:     "order follows input not sort",
+			input:    []string{"rust", "go", "rust", "csharp"},
+			expected: []string{"rust", "go", "csharp"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -206,3 +243,53 @@ func TestDeduplicate(t *testing.T) {
 		})
 	}
 }
+
+func TestLanguageTablesConsistent(t *testing.T) {
+	if len(LanguageList) != len(fs.SupportedExtensions) {
+		t.Fatalf("CLI lists %d languages, scanner supports %d", len(LanguageList), len(fs.SupportedExtensions))
+	}
+
+	for i, lang := range LanguageList {
+		if lang.ID != i+1 {
+			t.Errorf("%s has ID %d, want %d", lang.Name, lang.ID, i+1)
+		}
+
+		exts, ok := fs.SupportedExtensions[lang.key]
+		if !ok {
+			t.Errorf("CLI language %q is unknown to the scanner", lang.key)
+			continue
+		}
+		if !reflect.DeepEqual(SupportedLanguages[lang.key], exts) {
+			t.Errorf("%s extensions differ: CLI %v, scanner %v", lang.key, SupportedLanguages[lang.key], exts)
+		}
+	}
+}
+
+func TestWriteLanguages(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteLanguages("text", &buf); err != nil {
+		t.Fatalf("WriteLanguages failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != len(LanguageList) {
+		t.Fatalf("listed %d languages, want %d", len(lines), len(LanguageList))
+	}
+	if fields := strings.Fields(lines[0]); len(fields) < 4 || fields[0] != "1" || fields[1] != "go" || fields[3] != ".go" {
+		t.Errorf("unexpected first line %q", lines[0])
+	}
+	if !strings.Contains(buf.String(), "Dockerfile .dockerfile") {
+		t.Errorf("file names missing from listing:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteLanguages("json", &buf); err != nil {
+		t.Fatalf("WriteLanguages failed: %v", err)
+	}
+	var entries []languageEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(entries) != len(LanguageList) || entries[0].Key != "go" || entries[0].ID != 1 {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// reportWriter is a report destination. Close keeps what was written;
+// Discard drops it after a failed run.
+type reportWriter interface {
+	io.Writer
+	Close() error
+	Discard()
+}
+
+// stdoutWriter writes to stdout and leaves it open on Close
+type stdoutWriter struct {
+	io.Writer
+}
+
+func (stdoutWriter) Close() error {
+	return nil
+}
+
+// Discard does nothing; what reached stdout cannot be taken back
+func (stdoutWriter) Discard() {}
+
+// reportFile writes a report to a temporary file next to path and renames it
+// into place on Close, so a failed run leaves the previous report untouched
+type reportFile struct {
+	*os.File
+	path string
+}
+
+func (r *reportFile) Close() error {
+	if err := r.File.Close(); err != nil {
+		os.Remove(r.Name())
+		return err
+	}
+	if err := os.Rename(r.Name(), r.path); err != nil {
+		os.Remove(r.Name())
+		return err
+	}
+	return nil
+}
+
+func (r *reportFile) Discard() {
+	r.File.Close()
+	os.Remove(r.Name())
+}
+
+// openOutput returns the report destination: stdout when path is empty or "-",
+// otherwise the file at path with any missing parent directories created
+func openOutput(path string) (reportWriter, error) {
+	if path == "" || path == "-" {
+		return stdoutWriter{os.Stdout}, nil
+	}
+
+	dir := filepath.Dir(path)
+	if dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create output directory: %w", err)
+		}
+	}
+
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	// CreateTemp makes the file private; a report is as readable as os.Create makes it
+	if err := file.Chmod(0644); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return &reportFile{File: file, path: path}, nil
+}
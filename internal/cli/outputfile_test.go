@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenOutput_CreatesParentDirs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "nested", "scanr.json")
+
+	out, err := openOutput(path)
+	if err != nil {
+		t.Fatalf("openOutput failed: %v", err)
+	}
+	if _, err := out.Write([]byte("{}\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	if string(data) != "{}\n" {
+		t.Errorf("report = %q, want %q", data, "{}\n")
+	}
+}
+
+func TestOpenOutput_DiscardKeepsPreviousReport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "scanr.json")
+	if err := os.WriteFile(path, []byte("previous\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := openOutput(path)
+	if err != nil {
+		t.Fatalf("openOutput failed: %v", err)
+	}
+	if _, err := out.Write([]byte("partial")); err != nil {
+		t.Fatal(err)
+	}
+	out.Discard()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "previous\n" {
+		t.Errorf("report = %q, want the previous report kept", data)
+	}
+
+	// No temporary file is left behind
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the report", len(entries))
+	}
+}
+
+func TestOpenOutput_Stdout(t *testing.T) {
+	for _, path := range []string{"", "-"} {
+		out, err := openOutput(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := out.(stdoutWriter); !ok {
+			t.Errorf("path %q should write to stdout, got %T", path, out)
+		}
+		if err := out.Close(); err != nil {
+			t.Errorf("closing stdout writer failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"scanr/internal/config"
+	"scanr/internal/fs"
+)
+
+// resolvePathArgs expands explicit path arguments into files to review.
+// Directories are scanned recursively and glob patterns are expanded.
+func resolvePathArgs(ctx context.Context, cwd string, paths []string, languages []string, cfg *config.Config) ([]fs.FileInfo, error) {
+	filter, err := newFileFilter(cwd, languages, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []fs.FileInfo
+	seen := make(map[string]bool)
+
+	add := func(file fs.FileInfo) bool {
+		if seen[file.Path] {
+			return false
+		}
+		seen[file.Path] = true
+		files = append(files, file)
+		return cfg.MaxFiles > 0 && len(files) >= cfg.MaxFiles
+	}
+
+	for _, arg := range paths {
+		matches := []string{arg}
+		if strings.ContainsAny(arg, "*?[") {
+			var err error
+			matches, err = filepath.Glob(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %v", arg, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %q", arg)
+			}
+		}
+
+		for _, match := range matches {
+			path := match
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(cwd, path)
+			}
+			path = filepath.Clean(path)
+
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil, fmt.Errorf("cannot review %s: %v", match, err)
+			}
+
+			if info.IsDir() {
+				dirFiles, err := scanDirectory(ctx, cwd, path, languages, cfg, filter)
+				if err != nil {
+					return nil, err
+				}
+				for _, file := range dirFiles {
+					if add(file) {
+						return files, nil
+					}
+				}
+				continue
+			}
+
+			relPath, err := filepath.Rel(cwd, path)
+			if err != nil {
+				relPath = path
+			}
+
+			file, reason := filter.filter(path, relPath)
+			if reason != "" {
+				slog.Warn("skipping file", slog.String("path", match), slog.String("reason", reason))
+				continue
+			}
+			if add(file) {
+				return files, nil
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// scanDirectory scans dir and reports paths relative to cwd. The ignore
+// rules and excludes are matched from cwd by filter, as for file arguments,
+// not from dir.
+func scanDirectory(ctx context.Context, cwd, dir string, languages []string, cfg *config.Config, filter *fileFilter) ([]fs.FileInfo, error) {
+	scannerCfg := scannerConfig(dir, languages, cfg)
+	scannerCfg.ExcludePaths = nil
+	scannerCfg.IgnoreFile = ""
+	scanner, err := fs.NewScanner(scannerCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scanner for %s: %v", dir, err)
+	}
+
+	// Files are filtered after the scanner finds them, so it cannot stop at
+	// MaxFiles itself
+	scanCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	scanned := make(chan *fs.FileInfo)
+	scanErr := make(chan error, 1)
+	go func() {
+		scanErr <- scanner.ScanStream(scanCtx, 0, scanned)
+	}()
+
+	var files []fs.FileInfo
+	full := false
+	for file := range scanned {
+		if full || filter.ignore.Ignored(file.Path) {
+			continue
+		}
+		if relPath, err := filepath.Rel(cwd, file.Path); err == nil {
+			file.Relative = relPath
+		}
+		files = append(files, *file)
+		if cfg.MaxFiles > 0 && len(files) >= cfg.MaxFiles {
+			full = true
+			cancel()
+		}
+	}
+
+	if err := <-scanErr; err != nil && (!full || ctx.Err() != nil) {
+		return nil, fmt.Errorf("failed to scan %s: %v", dir, err)
+	}
+
+	return files, nil
+}
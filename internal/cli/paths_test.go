@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"scanr/internal/config"
+)
+
+func TestResolvePathArgs(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{
+		"cmd/main.go",
+		"internal/a.go",
+		"internal/sub/b.go",
+		"internal/notes.md",
+		"tools/x.go",
+		"tools/y.go",
+		"tools/z.py",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{MaxFiles: 100}
+	paths := []string{
+		"internal",
+		filepath.Join(root, "cmd", "main.go"),
+		filepath.Join(root, "tools", "*.go"),
+		"internal/a.go", // already covered by the directory
+	}
+
+	files, err := resolvePathArgs(context.Background(), root, paths, []string{"go"}, cfg)
+	if err != nil {
+		t.Fatalf("resolvePathArgs failed: %v", err)
+	}
+
+	var got []string
+	for _, f := range files {
+		got = append(got, filepath.ToSlash(f.Relative))
+	}
+	sort.Strings(got)
+
+	want := []string{"cmd/main.go", "internal/a.go", "internal/sub/b.go", "tools/x.go", "tools/y.go"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("file[%d] = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
+
+func TestResolvePathArgs_Errors(t *testing.T) {
+	root := t.TempDir()
+	cfg := &config.Config{MaxFiles: 100}
+
+	if _, err := resolvePathArgs(context.Background(), root, []string{"missing.go"}, []string{"go"}, cfg); err == nil {
+		t.Error("expected error for missing path")
+	}
+	if _, err := resolvePathArgs(context.Background(), root, []string{filepath.Join(root, "*.go")}, []string{"go"}, cfg); err == nil {
+		t.Error("expected error for glob without matches")
+	}
+}
+
+func TestResolvePathArgs_DirectoryIgnoreRules(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"internal/gen/a.go": "package gen\n",
+		"internal/app/b.go": "package app\n",
+		"internal/c.go":     "package internal\n",
+		".scanrignore":      "internal/gen/\n",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Rules in the root .scanrignore apply under a directory argument too
+	files, err := resolvePathArgs(context.Background(), root, []string{"internal"}, []string{"go"}, &config.Config{MaxFiles: 100})
+	if err != nil {
+		t.Fatalf("resolvePathArgs failed: %v", err)
+	}
+	got := relativePaths(files)
+	sort.Strings(got)
+	if strings.Join(got, ",") != "internal/app/b.go,internal/c.go" {
+		t.Errorf("got %v, want internal/gen ignored", got)
+	}
+}
+
+func TestResolvePathArgs_DirectoryExcludePaths(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"internal/app/b.go", "internal/c.go", "app/d.go"} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Globs are relative to cwd, not to the directory argument: app/** must
+	// not exclude internal/app
+	cfg := &config.Config{MaxFiles: 100, ExcludePaths: []string{"internal/app/**", "app/**"}}
+	files, err := resolvePathArgs(context.Background(), root, []string{"internal", "app"}, []string{"go"}, cfg)
+	if err != nil {
+		t.Fatalf("resolvePathArgs failed: %v", err)
+	}
+	if got := relativePaths(files); strings.Join(got, ",") != "internal/c.go" {
+		t.Errorf("got %v, want only internal/c.go", got)
+	}
+
+	cfg.ExcludePaths = []string{"app/**"}
+	files, err = resolvePathArgs(context.Background(), root, []string{"internal"}, []string{"go"}, cfg)
+	if err != nil {
+		t.Fatalf("resolvePathArgs failed: %v", err)
+	}
+	got := relativePaths(files)
+	sort.Strings(got)
+	if strings.Join(got, ",") != "internal/app/b.go,internal/c.go" {
+		t.Errorf("got %v, want app/** to leave internal/app alone", got)
+	}
+}
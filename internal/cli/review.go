@@ -3,11 +3,14 @@ package cli
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"scanr/internal/config"
 	"scanr/internal/fs"
@@ -19,10 +22,35 @@ import (
 
 // RunReview is the main entry point for the review command
 func RunReview(ctx context.Context, cfg *config.Config) (int, error) {
-	// Parse or prompt for languages
-	languages, err := ParseLanguages(cfg.Languages)
+	out, err := openOutput(cfg.Output)
 	if err != nil {
-		return 2, fmt.Errorf("failed to parse languages: %v", err)
+		return 2, err
+	}
+
+	// A failed run keeps the previous report, which diff-runs may compare against
+	exitCode, err := runReview(ctx, cfg, out)
+	if err != nil {
+		out.Discard()
+		return exitCode, err
+	}
+	if closeErr := out.Close(); closeErr != nil {
+		return 2, fmt.Errorf("failed to write output: %w", closeErr)
+	}
+	return exitCode, err
+}
+
+// runReview reviews the selected files and writes the report to out
+func runReview(ctx context.Context, cfg *config.Config, out io.Writer) (int, error) {
+	// Parse or prompt for languages; stdin is reserved for the file list
+	var languages []string
+	var err error
+	if cfg.Stdin && strings.TrimSpace(cfg.Languages) == "" {
+		languages = allLanguages()
+	} else {
+		languages, err = ParseLanguages(cfg.Languages)
+		if err != nil {
+			return 2, fmt.Errorf("failed to parse languages: %v", err)
+		}
 	}
 
 	// Get current directory
@@ -31,24 +59,88 @@ func RunReview(ctx context.Context, cfg *config.Config) (int, error) {
 		return 2, fmt.Errorf("failed to get current directory: %v", err)
 	}
 
-	// Get files to review
-	files, _, err := getFilesToReview(ctx, cwd, languages, cfg)
+	// Get files to review. Outside a git repository the files come from a
+	// scanner instead, which feeds the pipeline while it walks the tree.
+	var files []fs.FileInfo
+	var scanner *fs.Scanner
+	if cfg.Stdin {
+		files, err = readFileList(os.Stdin, cwd, languages, cfg)
+	} else {
+		var repo *git.Repository
+		files, scanner, repo, err = getFilesToReview(ctx, cwd, languages, cfg)
+		if err == nil && repo != nil && repo.IsBare && repo.WorkTree != repo.Path {
+			defer os.RemoveAll(repo.WorkTree)
+		}
+	}
 	if err != nil {
 		return 2, fmt.Errorf("failed to get files: %v", err)
 	}
 
-	if len(files) == 0 {
-		log.Println("No files found to review")
+	// Preview the selection without constructing a reviewer
+	if cfg.DryRun {
+		if scanner != nil {
+			if files, err = scanner.Scan(ctx, cfg.MaxFiles); err != nil {
+				return 2, fmt.Errorf("failed to get files: %v", err)
+			}
+		}
+		if err := writeDryRun(files, cfg.Format, out); err != nil {
+			return 2, fmt.Errorf("failed to write dry run: %w", err)
+		}
+		return 0, nil
+	}
+
+	if scanner == nil && len(files) == 0 {
+		slog.Warn("no files found to review")
 		return 0, nil
 	}
 
-	log.Printf("Found %d file(s) to review", len(files))
+	slog.Debug("found files to review", slog.Int("files", len(files)))
+
+	// Create output formatter
+	factory := output.NewFormatterFactory()
+	colorMode := output.ColorMode(cfg.Color)
+	wrapWidth := cfg.Wrap
+	if cfg.Output != "" && cfg.Output != "-" {
+		// A report file is not a terminal
+		if colorMode != output.ColorAlways {
+			colorMode = output.ColorNever
+		}
+		if wrapWidth < 0 {
+			wrapWidth = 0
+		}
+	}
+	if wrapWidth < 0 {
+		wrapWidth = output.TerminalWidth()
+	}
+	csvSeparator, err := config.ParseCSVSeparator(cfg.CSVSeparator)
+	if err != nil {
+		return 2, err
+	}
+	formatter, err := factory.CreateFormatterFromFlags(cfg.Format, colorMode,
+		output.WithShowSuppressed(cfg.ShowSuppressed),
+		output.WithContextLines(cfg.ContextLines),
+		output.WithWrapWidth(wrapWidth),
+		output.WithShowFixes(cfg.ShowFixes),
+		output.WithGroupBy(cfg.GroupBy),
+		output.WithCSVSeparator(csvSeparator),
+		output.WithNoHeader(cfg.CSVNoHeader),
+		output.WithMinConfidence(cfg.MinConfidence),
+	)
+	if err != nil {
+		return 2, fmt.Errorf("failed to create formatter: %w", err)
+	}
 
 	// Create mock reviewer for now
-	mockReviewer := reviewer.NewMockReviewer("scanr-mock")
+	mockReviewer := reviewer.NewMockReviewer("scanr-mock", reviewer.WithFocusAreas(cfg.FocusAreas...))
 
 	// Create review pipeline
-	pipeline, err := review.NewPipeline(review.DefaultConfig(), mockReviewer)
+	pipelineCfg := newPipelineConfig(cfg)
+	var progress *output.ProgressReporter
+	if cfg.Progress {
+		progress = output.NewProgressReporter(os.Stderr)
+		pipelineCfg.OnProgress = progress.Update
+	}
+	pipeline, err := review.NewPipeline(pipelineCfg, mockReviewer)
 	if err != nil {
 		return 2, fmt.Errorf("failed to create review pipeline: %v", err)
 	}
@@ -59,22 +151,51 @@ func RunReview(ctx context.Context, cfg *config.Config) (int, error) {
 	for i := range files {
 		filePointers[i] = &files[i]
 	}
-	result, err := pipeline.Run(ctx, filePointers)
-	if err != nil {
-		return 2, fmt.Errorf("review failed: %v", err)
+	run := func(results chan<- *review.FileReview) (*review.ReviewResult, error) {
+		if scanner != nil {
+			return reviewScan(ctx, pipeline, scanner, cfg.MaxFiles, results)
+		}
+		if results != nil {
+			return pipeline.RunStream(ctx, filePointers, results)
+		}
+		return pipeline.Run(ctx, filePointers)
 	}
 
-	// Create output formatter
-	factory := output.NewFormatterFactory()
-	formatter, err := factory.CreateFormatterFromFlags(cfg.Format, true)
-	if err != nil {
-		return 2, fmt.Errorf("failed to create formatter: %w", err)
+	if cfg.Stream {
+		result, err := runStreamingReview(formatter, run, out)
+		if progress != nil {
+			progress.Done()
+		}
+		if err != nil {
+			return 2, err
+		}
+		if err := dumpFailures(pipeline, cfg.DumpFailures); err != nil {
+			return 2, err
+		}
+		return output.DetermineExitCode(result), nil
+	}
+
+	// An interrupted run still returns the reviews finished so far, which
+	// are printed like a complete result
+	result, err := run(nil)
+	if progress != nil {
+		progress.Done()
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return 2, fmt.Errorf("review failed: %v", err)
+	}
+	if scanner != nil && result.TotalFiles == 0 && !result.Interrupted {
+		slog.Warn("no files found to review")
+		return 0, nil
 	}
 
 	// Format and display results
-	if err := formatter.Format(result, os.Stdout); err != nil {
+	if err := formatter.Format(result, out); err != nil {
 		return 2, fmt.Errorf("failed to format output: %w", err)
 	}
+	if err := dumpFailures(pipeline, cfg.DumpFailures); err != nil {
+		return 2, err
+	}
 
 	// Determine exit code
 	exitCode := output.DetermineExitCode(result)
@@ -82,144 +203,297 @@ func RunReview(ctx context.Context, cfg *config.Config) (int, error) {
 	return exitCode, nil
 }
 
-// getFilesToReview gets files to review based on git status or full scan
-func getFilesToReview(ctx context.Context, cwd string, languages []string, cfg *config.Config) ([]fs.FileInfo, *git.Repository, error) {
+// dumpFailures writes the files still failing after retries to path, if set
+func dumpFailures(pipeline review.Pipeline, path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := pipeline.DumpDeadLetters(path); err != nil {
+		return fmt.Errorf("failed to write failures to %s: %v", path, err)
+	}
+	return nil
+}
+
+// runStreamingReview prints each file review as run finishes it. run must
+// close the stream it is given.
+func runStreamingReview(formatter output.Formatter, run func(chan<- *review.FileReview) (*review.ReviewResult, error), out io.Writer) (*review.ReviewResult, error) {
+	stream := make(chan *review.FileReview)
+	formatErr := make(chan error, 1)
+
+	go func() {
+		err := formatter.FormatStream(stream, out)
+		// Keep draining so the pipeline never blocks on a failed writer
+		for range stream {
+		}
+		formatErr <- err
+	}()
+
+	result, err := run(stream)
+	if streamErr := <-formatErr; streamErr != nil && err == nil {
+		return nil, fmt.Errorf("failed to format output: %w", streamErr)
+	}
+	// Reviews finished before an interrupt have already been streamed
+	if errors.Is(err, context.Canceled) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("review failed: %v", err)
+	}
+
+	return result, nil
+}
+
+// getFilesToReview gets files to review from explicit paths or git status.
+// Explicit paths take precedence over --staged. Outside a git repository it
+// returns a scanner for the whole tree instead of a file list.
+func getFilesToReview(ctx context.Context, cwd string, languages []string, cfg *config.Config) ([]fs.FileInfo, *fs.Scanner, *git.Repository, error) {
+	if len(cfg.Paths) > 0 {
+		slog.Debug("reviewing explicit paths", slog.Int("paths", len(cfg.Paths)))
+		files, err := resolvePathArgs(ctx, cwd, cfg.Paths, languages, cfg)
+		return files, nil, nil, err
+	}
+
 	// Detect git repository
 	repo, err := git.DetectRepository(cwd)
+	if err != nil && cfg.Since != "" {
+		return nil, nil, nil, fmt.Errorf("--since requires a git repository: %v", err)
+	}
 	if err != nil {
-		log.Printf("Warning: Not a git repository (%v), scanning all files", err)
-		files, err := scanAllFiles(ctx, cwd, languages, cfg.MaxFiles)
-		return files, nil, err
+		slog.Warn("not a git repository, scanning all files", slog.Any("error", err))
+		scanner, err := newTreeScanner(cwd, languages, cfg)
+		return nil, scanner, nil, err
 	}
 
-	log.Printf("Found git repository at: %s", repo.Path)
+	slog.Debug("found git repository", slog.String("path", repo.Path))
+
+	// A bare repository has no index or work tree, so review a whole branch
+	if repo.IsBare {
+		files, err := getBranchFiles(ctx, repo, languages, cfg)
+		return files, nil, repo, err
+	}
 
-	// Get git changes based on staged flag
+	// Get git changes based on the since ref or the staged flag
 	var changes []git.FileChange
-	if cfg.StagedOnly {
+	if cfg.Since != "" {
+		paths, err := repo.GetChangedFilesSince(ctx, cfg.Since)
+		if err != nil {
+			return nil, nil, repo, fmt.Errorf("failed to get changes since %s: %v", cfg.Since, err)
+		}
+		for _, path := range paths {
+			changes = append(changes, git.FileChange{Path: path, ChangeType: git.ChangeModified})
+		}
+		slog.Debug("found files changed since ref", slog.String("ref", cfg.Since), slog.Int("files", len(changes)))
+	} else if cfg.StagedOnly {
 		changes, err = repo.GetStagedChanges(ctx)
 		if err != nil {
-			return nil, repo, fmt.Errorf("failed to get staged changes: %v", err)
+			return nil, nil, repo, fmt.Errorf("failed to get staged changes: %v", err)
 		}
-		log.Printf("Found %d staged file(s)", len(changes))
+		slog.Debug("found staged files", slog.Int("files", len(changes)))
 	} else {
-		changes, err = repo.GetAllChanges(ctx)
+		changes, err = repo.GetStatus(ctx, git.StatusOptions{
+			IncludeRenames:   true,
+			IncludeUntracked: cfg.IncludeUntracked,
+		})
 		if err != nil {
-			return nil, repo, fmt.Errorf("failed to get changes: %v", err)
+			return nil, nil, repo, fmt.Errorf("failed to get changes: %v", err)
 		}
-		log.Printf("Found %d changed file(s)", len(changes))
+		slog.Debug("found changed files", slog.Int("files", len(changes)))
 	}
 
 	// Filter changes by language
-	files, err := filterAndConvertChanges(repo, changes, languages, cfg.MaxFiles)
+	files, err := filterAndConvertChanges(repo, changes, languages, cfg)
 	if err != nil {
-		return nil, repo, fmt.Errorf("failed to process changes: %v", err)
+		return nil, nil, repo, fmt.Errorf("failed to process changes: %v", err)
 	}
 
-	return files, repo, nil
+	return files, nil, repo, nil
 }
 
-// scanAllFiles handles non-git repository scanning
-func scanAllFiles(ctx context.Context, cwd string, languages []string, maxFiles int) ([]fs.FileInfo, error) {
-	log.Println("Scanning all files (not a git repository)")
+// getBranchFiles reviews every file on cfg.Branch of a bare repository. The
+// branch is exported to a temporary directory that becomes repo.WorkTree; the
+// caller removes it once the review is done.
+func getBranchFiles(ctx context.Context, repo *git.Repository, languages []string, cfg *config.Config) ([]fs.FileInfo, error) {
+	if cfg.Since != "" {
+		return nil, fmt.Errorf("--since is not supported in a bare repository, use --branch")
+	}
 
-	// Create filesystem scanner
-	scanner, err := fs.NewScanner(fs.Config{
-		RootDir:     cwd,
-		Languages:   languages,
-		MaxFileSize: 1024 * 1024, // 1MB
-		MaxLines:    1000,
-		IgnoreDirs:  []string{},
-	})
+	branch := cfg.Branch
+	if branch == "" {
+		branch = "HEAD"
+	}
+
+	changes, err := repo.GetBranchFiles(ctx, branch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create scanner: %v", err)
+		return nil, fmt.Errorf("failed to list files on %s: %v", branch, err)
+	}
+	slog.Debug("found files on branch", slog.String("branch", branch), slog.Int("files", len(changes)))
+
+	dir, err := os.MkdirTemp("", "scanr-bare-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create work tree: %v", err)
+	}
+	if err := repo.ExportTree(ctx, branch, dir); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to export %s: %v", branch, err)
 	}
+	repo.WorkTree = dir
 
-	// Scan for files
-	return scanner.Scan(ctx, maxFiles)
+	files, err := filterAndConvertChanges(repo, changes, languages, cfg)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to process files: %v", err)
+	}
+	return files, nil
 }
 
-// filterAndConvertChanges filters git changes by language and converts to FileInfo
-func filterAndConvertChanges(repo *git.Repository, changes []git.FileChange, languages []string, maxFiles int) ([]fs.FileInfo, error) {
-	// Build language extensions map for filtering
-	langExts := make(map[string]bool)
-	for _, lang := range languages {
-		exts, ok := fs.SupportedExtensions[lang]
-		if !ok {
-			continue
-		}
-		for _, ext := range exts {
-			langExts[ext] = true
-		}
+// newTreeScanner creates the scanner for non-git repository scanning
+func newTreeScanner(cwd string, languages []string, cfg *config.Config) (*fs.Scanner, error) {
+	slog.Debug("scanning all files", slog.String("root", cwd))
+
+	scanner, err := fs.NewScanner(scannerConfig(cwd, languages, cfg))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scanner: %v", err)
 	}
+	return scanner, nil
+}
 
-	var files []fs.FileInfo
-	fileCount := 0
+// reviewScan reviews files as the scanner finds them, so the first reviews
+// start before the walk finishes. results may be nil, as for RunChannel.
+func reviewScan(ctx context.Context, pipeline review.Pipeline, scanner *fs.Scanner, maxFiles int,
+	results chan<- *review.FileReview) (*review.ReviewResult, error) {
+	scanCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	for _, change := range changes {
-		// Skip deleted files
-		if change.ChangeType == git.ChangeDeleted {
-			continue
-		}
+	scanned := make(chan *fs.FileInfo)
+	scanErr := make(chan error, 1)
+	go func() {
+		scanErr <- scanner.ScanStream(scanCtx, maxFiles, scanned)
+	}()
 
-		// Check file extension
-		ext := strings.ToLower(filepath.Ext(change.Path))
-		if !langExts[ext] {
-			continue
-		}
+	result, err := pipeline.RunChannel(ctx, scanned, results)
 
-		// Get file info
-		fullPath := filepath.Join(repo.Path, change.Path)
-		info, err := os.Stat(fullPath)
-		if err != nil {
-			// File might not exist (e.g., for staged deletions)
-			continue
-		}
+	// A pipeline that stopped early no longer reads from scanned
+	cancel()
+	for range scanned {
+	}
+	if serr := <-scanErr; serr != nil && err == nil && ctx.Err() == nil {
+		return nil, fmt.Errorf("failed to scan files: %v", serr)
+	}
+	return result, err
+}
 
-		// Check size limit
-		if info.Size() > 1024*1024 { // 1MB
-			continue
-		}
+// fileLimits are the size and line limits applied to every reviewed file
+type fileLimits struct {
+	maxFileSize int64
+	maxLines    int
+}
 
-		// Count lines
-		lines, err := countFileLines(fullPath)
+// newFileLimits reads the limits from cfg, using the scanner defaults when unset
+func newFileLimits(cfg *config.Config) fileLimits {
+	limits := fileLimits{maxFileSize: cfg.MaxFileSize, maxLines: cfg.MaxLines}
+	if limits.maxFileSize <= 0 {
+		limits.maxFileSize = fs.DefaultMaxFileSize
+	}
+	if limits.maxLines <= 0 {
+		limits.maxLines = fs.DefaultMaxLines
+	}
+	return limits
+}
+
+// newPipelineConfig applies the CLI settings to the default pipeline configuration
+func newPipelineConfig(cfg *config.Config) review.Config {
+	pipelineCfg := review.DefaultConfig()
+	if cfg.Workers > 0 {
+		pipelineCfg.MaxWorkers = cfg.Workers
+	}
+	if cfg.QueueSize > 0 {
+		pipelineCfg.MaxQueueSize = cfg.QueueSize
+	}
+	if cfg.TimeoutPerLine > 0 {
+		pipelineCfg.TimeoutPerLine = cfg.TimeoutPerLine
+	}
+	// A fixed timeout replaces the one scaled by file length
+	if cfg.PerFileTimeout > 0 {
+		pipelineCfg.TimeoutPerFile = cfg.PerFileTimeout
+		pipelineCfg.TimeoutPerLine = 0
+	}
+	// ValidateConfig rejects zero, so it only means unset for configs built
+	// without the CLI flags
+	if cfg.DefaultConfidence > 0 {
+		pipelineCfg.DefaultConfidence = cfg.DefaultConfidence
+	}
+	pipelineCfg.MinConfidence = cfg.MinConfidence
+	pipelineCfg.FocusAreas = cfg.FocusAreas
+	if cfg.PersistFailures {
+		dir, err := CacheDir()
+		if err == nil {
+			err = initCacheDir(dir)
+		}
 		if err != nil {
-			continue
+			slog.Warn("not persisting failed files", slog.Any("error", err))
+		} else {
+			pipelineCfg.DeadLetterFile = deadLetterFile(dir, time.Now())
 		}
+	}
+	return pipelineCfg
+}
+
+// deadLetterFile names the file a run started at start records failed files in
+func deadLetterFile(cacheDir string, start time.Time) string {
+	name := fmt.Sprintf("%s-%d.jsonl", start.Format("20060102-150405"), os.Getpid())
+	return filepath.Join(cacheDir, "deadletters", name)
+}
 
-		// Check line limit
-		if lines > 1000 {
+// scannerConfig builds the filesystem scanner settings shared by every scan
+func scannerConfig(root string, languages []string, cfg *config.Config) fs.Config {
+	limits := newFileLimits(cfg)
+	return fs.Config{
+		RootDir:       root,
+		Languages:     languages,
+		MaxFileSize:   limits.maxFileSize,
+		MaxLines:      limits.maxLines,
+		IgnoreDirs:    []string{},
+		ExcludePaths:  cfg.ExcludePaths,
+		IgnoreFile:    cfg.IgnoreFile,
+		SkipBinary:    true,
+		SkipTestFiles: cfg.SkipTestFiles,
+		SkipGenerated: !cfg.IncludeGenerated,
+	}
+}
+
+// filterAndConvertChanges filters git changes by language and converts to FileInfo
+func filterAndConvertChanges(repo *git.Repository, changes []git.FileChange, languages []string, cfg *config.Config) ([]fs.FileInfo, error) {
+	// Files are read from the work tree, which is an export for bare repositories
+	root := repo.WorkTree
+	if root == "" {
+		root = repo.Path
+	}
+
+	filter, err := newFileFilter(root, languages, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []fs.FileInfo
+	for _, change := range changes {
+		// Skip deleted files
+		if change.ChangeType == git.ChangeDeleted {
 			continue
 		}
 
-		// Determine language from extension
-		language := ""
-		for lang, exts := range fs.SupportedExtensions {
-			for _, e := range exts {
-				if ext == e {
-					language = lang
-					break
-				}
-			}
-			if language != "" {
-				break
-			}
+		// Untracked files may be build output or editor files
+		if change.ChangeType == git.ChangeUnknown && !cfg.IncludeUntracked {
+			continue
 		}
 
-		if language == "" {
+		file, reason := filter.filter(filepath.Join(root, change.Path), change.Path)
+		if reason != "" {
+			slog.Debug("skipping file", slog.String("path", change.Path), slog.String("reason", reason))
 			continue
 		}
+		files = append(files, file)
 
-		files = append(files, fs.FileInfo{
-			Path:      fullPath,
-			Size:      info.Size(),
-			Lines:     lines,
-			Languages: language,
-			Relative:  change.Path,
-		})
-
-		fileCount++
-		if maxFiles > 0 && fileCount >= maxFiles {
+		if cfg.MaxFiles > 0 && len(files) >= cfg.MaxFiles {
 			break
 		}
 	}
@@ -227,8 +501,8 @@ func filterAndConvertChanges(repo *git.Repository, changes []git.FileChange, lan
 	return files, nil
 }
 
-// countFileLines counts lines in a file
-func countFileLines(path string) (int, error) {
+// countFileLines counts lines in a file, stopping once it exceeds maxLines
+func countFileLines(path string, maxLines int) (int, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return 0, err
@@ -239,7 +513,7 @@ func countFileLines(path string) (int, error) {
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		count++
-		if count > 1000 {
+		if count > maxLines {
 			break
 		}
 	}
@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scanr/internal/config"
+	"scanr/internal/git"
+	"scanr/internal/review"
+	"scanr/pkg/reviewer"
+)
+
+func TestFilterAndConvertChanges_SelectedLanguagesOnly(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "script.py", "app.ts", "removed.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("line\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes := []git.FileChange{
+		{Path: "main.go", ChangeType: git.ChangeAdded},
+		{Path: "script.py", ChangeType: git.ChangeAdded},
+		{Path: "app.ts", ChangeType: git.ChangeModified},
+		{Path: "removed.go", ChangeType: git.ChangeDeleted},
+	}
+
+	files, err := filterAndConvertChanges(&git.Repository{Path: dir}, changes, []string{"go"}, &config.Config{MaxFiles: 10})
+	if err != nil {
+		t.Fatalf("filterAndConvertChanges failed: %v", err)
+	}
+
+	if len(files) != 1 {
+		t.Fatalf("expected only main.go, got %+v", files)
+	}
+	if files[0].Relative != "main.go" || files[0].Languages != "go" {
+		t.Errorf("file = %s (%s), want main.go (go)", files[0].Relative, files[0].Languages)
+	}
+}
+
+func TestFilterAndConvertChanges_SharedExtension(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "Program.cs"), []byte("class Program {}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	changes := []git.FileChange{{Path: "Program.cs", ChangeType: git.ChangeAdded}}
+
+	// .cs belongs to both csharp and dotnet; the selected one wins
+	for _, lang := range []string{"csharp", "dotnet"} {
+		files, err := filterAndConvertChanges(&git.Repository{Path: dir}, changes, []string{lang}, &config.Config{MaxFiles: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(files) != 1 || files[0].Languages != lang {
+			t.Errorf("with --lang=%s got %+v", lang, files)
+		}
+	}
+}
+
+func TestFilterAndConvertChanges_SkipTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"app.go", "app_test.go", "test_util.py"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("line\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes := []git.FileChange{
+		{Path: "app.go", ChangeType: git.ChangeModified},
+		{Path: "app_test.go", ChangeType: git.ChangeModified},
+		{Path: "test_util.py", ChangeType: git.ChangeAdded},
+	}
+	languages := []string{"go", "python"}
+
+	files, err := filterAndConvertChanges(&git.Repository{Path: dir}, changes, languages, &config.Config{MaxFiles: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Errorf("test files are included by default, got %d files", len(files))
+	}
+
+	cfg := &config.Config{MaxFiles: 10, SkipTestFiles: true}
+	files, err = filterAndConvertChanges(&git.Repository{Path: dir}, changes, languages, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Relative != "app.go" {
+		t.Errorf("with SkipTestFiles got %+v, want only app.go", files)
+	}
+}
+
+func TestFilterAndConvertChanges_ScanrIgnore(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "testdata"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"main.go", "testdata/fixture.go", "legacy.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("line\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".scanrignore"), []byte("testdata/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	extraIgnore := filepath.Join(t.TempDir(), "extra-ignore")
+	if err := os.WriteFile(extraIgnore, []byte("legacy.go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := []git.FileChange{
+		{Path: "main.go", ChangeType: git.ChangeModified},
+		{Path: "testdata/fixture.go", ChangeType: git.ChangeAdded},
+		{Path: "legacy.go", ChangeType: git.ChangeModified},
+	}
+
+	cfg := &config.Config{MaxFiles: 10, IgnoreFile: extraIgnore}
+	files, err := filterAndConvertChanges(&git.Repository{Path: dir}, changes, []string{"go"}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Relative != "main.go" {
+		t.Errorf("got %+v, want only main.go", files)
+	}
+}
+
+func TestFilterAndConvertChanges_ExcludePaths(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"main.go", "proto/api.pb.go", "internal/mocks/store.go", "internal/store.go"}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("line\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var changes []git.FileChange
+	for _, name := range names {
+		changes = append(changes, git.FileChange{Path: name, ChangeType: git.ChangeModified})
+	}
+
+	cfg := &config.Config{MaxFiles: 10, ExcludePaths: []string{"proto/", "**/mocks/**"}}
+	files, err := filterAndConvertChanges(&git.Repository{Path: dir}, changes, []string{"go"}, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, file := range files {
+		got = append(got, file.Relative)
+	}
+	if strings.Join(got, ",") != "main.go,internal/store.go" {
+		t.Errorf("got %v, want main.go and internal/store.go", got)
+	}
+}
+
+func TestFilterAndConvertChanges_FileLimits(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"small.go": "package a\n",
+		"long.go":  strings.Repeat("// line\n", 20),
+		"large.go": "package a\n// " + strings.Repeat("x", 200) + "\n",
+	}
+	var changes []git.FileChange
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		changes = append(changes, git.FileChange{Path: name, ChangeType: git.ChangeModified})
+	}
+
+	cfg := &config.Config{MaxFiles: 10, MaxFileSize: 100, MaxLines: 10}
+	got, err := filterAndConvertChanges(&git.Repository{Path: dir}, changes, []string{"go"}, cfg)
+	if err != nil {
+		t.Fatalf("filterAndConvertChanges failed: %v", err)
+	}
+
+	if len(got) != 1 || got[0].Relative != "small.go" {
+		t.Errorf("files = %+v, want only small.go within the limits", got)
+	}
+}
+
+func TestNewPipelineConfig_Workers(t *testing.T) {
+	pipelineCfg := newPipelineConfig(&config.Config{Workers: 7, QueueSize: 3})
+
+	p, err := review.NewPipeline(pipelineCfg, reviewer.NewMockReviewer("mock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	if capacity := p.Stats()["capacity"]; capacity != 7 {
+		t.Errorf("pool capacity = %d, want 7", capacity)
+	}
+	if pipelineCfg.MaxQueueSize != 3 {
+		t.Errorf("MaxQueueSize = %d, want 3", pipelineCfg.MaxQueueSize)
+	}
+
+	// Unset values keep the pipeline defaults
+	if got := newPipelineConfig(&config.Config{}).MaxWorkers; got != review.DefaultConfig().MaxWorkers {
+		t.Errorf("default MaxWorkers = %d, want %d", got, review.DefaultConfig().MaxWorkers)
+	}
+}
+
+func TestReviewScan(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package a\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, stream := range []bool{false, true} {
+		scanner, err := newTreeScanner(dir, []string{"go"}, &config.Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := review.NewPipeline(review.DefaultConfig(), reviewer.NewMockReviewer("mock"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var results chan *review.FileReview
+		streamed := make(chan int, 1)
+		if stream {
+			results = make(chan *review.FileReview)
+			go func() {
+				n := 0
+				for range results {
+					n++
+				}
+				streamed <- n
+			}()
+		}
+
+		result, err := reviewScan(context.Background(), p, scanner, 0, results)
+		p.Stop()
+		if err != nil {
+			t.Fatalf("stream=%v: reviewScan failed: %v", stream, err)
+		}
+		if result.TotalFiles != 3 || result.ReviewedFiles != 3 {
+			t.Errorf("stream=%v: total %d, reviewed %d, want 3 and 3", stream, result.TotalFiles, result.ReviewedFiles)
+		}
+		if stream {
+			if n := <-streamed; n != 3 {
+				t.Errorf("streamed %d file reviews, want 3", n)
+			}
+		}
+	}
+}
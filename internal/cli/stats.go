@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"scanr/internal/config"
+	"scanr/internal/fs"
+)
+
+// charsPerToken is the heuristic used to estimate prompt tokens from file size
+const charsPerToken = 4
+
+// StatsReport summarises the files scanr would review
+type StatsReport struct {
+	TotalFiles      int             `json:"total_files"`
+	TotalSize       int64           `json:"total_size"`
+	TotalLines      int             `json:"total_lines"`
+	AvgSize         float64         `json:"avg_size"`
+	MaxSize         int64           `json:"max_size"`
+	MinSize         int64           `json:"min_size"`
+	AvgLines        float64         `json:"avg_lines"`
+	MaxLines        int             `json:"max_lines"`
+	EstimatedTokens int64           `json:"estimated_tokens"`
+	Languages       []LanguageStats `json:"languages"`
+}
+
+// LanguageStats holds per-language totals
+type LanguageStats struct {
+	Language        string   `json:"language"`
+	Extensions      []string `json:"extensions"`
+	Files           int      `json:"files"`
+	Size            int64    `json:"size"`
+	Lines           int      `json:"lines"`
+	EstimatedTokens int64    `json:"estimated_tokens"`
+}
+
+// RunStats scans the current directory and prints file statistics without reviewing anything
+func RunStats(ctx context.Context, cfg *config.Config, w io.Writer) error {
+	languages, err := ParseLanguages(cfg.Languages)
+	if err != nil {
+		return fmt.Errorf("failed to parse languages: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %v", err)
+	}
+
+	scanner, err := fs.NewScanner(scannerConfig(cwd, languages, cfg))
+	if err != nil {
+		return fmt.Errorf("failed to create scanner: %v", err)
+	}
+
+	files, err := scanner.Scan(ctx, cfg.MaxFiles)
+	if err != nil {
+		return fmt.Errorf("failed to scan files: %v", err)
+	}
+
+	report := buildStats(files, languages)
+
+	if cfg.Format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	writeStatsText(report, w)
+	return nil
+}
+
+// buildStats aggregates scanned files into a report
+func buildStats(files []fs.FileInfo, languages []string) StatsReport {
+	report := StatsReport{}
+	byLanguage := make(map[string]*LanguageStats)
+
+	for _, lang := range languages {
+		byLanguage[lang] = &LanguageStats{
+			Language:   lang,
+			Extensions: fs.SupportedExtensions[lang],
+		}
+	}
+
+	for i, file := range files {
+		report.TotalFiles++
+		report.TotalSize += file.Size
+		report.TotalLines += file.Lines
+
+		if file.Size > report.MaxSize {
+			report.MaxSize = file.Size
+		}
+		if i == 0 || file.Size < report.MinSize {
+			report.MinSize = file.Size
+		}
+		if file.Lines > report.MaxLines {
+			report.MaxLines = file.Lines
+		}
+
+		stats, ok := byLanguage[file.Languages]
+		if !ok {
+			stats = &LanguageStats{
+				Language:   file.Languages,
+				Extensions: fs.SupportedExtensions[file.Languages],
+			}
+			byLanguage[file.Languages] = stats
+		}
+		stats.Files++
+		stats.Size += file.Size
+		stats.Lines += file.Lines
+	}
+
+	if report.TotalFiles > 0 {
+		report.AvgSize = float64(report.TotalSize) / float64(report.TotalFiles)
+		report.AvgLines = float64(report.TotalLines) / float64(report.TotalFiles)
+	}
+	report.EstimatedTokens = report.TotalSize / charsPerToken
+
+	for _, stats := range byLanguage {
+		stats.EstimatedTokens = stats.Size / charsPerToken
+		report.Languages = append(report.Languages, *stats)
+	}
+
+	sort.Slice(report.Languages, func(i, j int) bool {
+		if report.Languages[i].Files == report.Languages[j].Files {
+			return report.Languages[i].Language < report.Languages[j].Language
+		}
+		return report.Languages[i].Files > report.Languages[j].Files
+	})
+
+	return report
+}
+
+// writeStatsText writes the report as a human-readable table
+func writeStatsText(report StatsReport, w io.Writer) {
+	fmt.Fprintf(w, "SCAN STATISTICS\n")
+	fmt.Fprintf(w, "%s\n", strings.Repeat("-", 40))
+
+	fmt.Fprintf(w, "Files:       %d\n", report.TotalFiles)
+	fmt.Fprintf(w, "Total size:  %s\n", formatBytes(report.TotalSize))
+	fmt.Fprintf(w, "Total lines: %d\n", report.TotalLines)
+
+	if report.TotalFiles > 0 {
+		fmt.Fprintf(w, "\nFile size:\n")
+		fmt.Fprintf(w, "  Average:   %s\n", formatBytes(int64(report.AvgSize)))
+		fmt.Fprintf(w, "  Max:       %s\n", formatBytes(report.MaxSize))
+		fmt.Fprintf(w, "  Min:       %s\n", formatBytes(report.MinSize))
+
+		fmt.Fprintf(w, "\nLine count:\n")
+		fmt.Fprintf(w, "  Average:   %.1f\n", report.AvgLines)
+		fmt.Fprintf(w, "  Max:       %d\n", report.MaxLines)
+	}
+
+	fmt.Fprintf(w, "\n%-12s %-20s %7s %10s %8s %10s\n",
+		"LANGUAGE", "EXTENSIONS", "FILES", "SIZE", "LINES", "TOKENS")
+	for _, lang := range report.Languages {
+		fmt.Fprintf(w, "%-12s %-20s %7d %10s %8d %10d\n",
+			lang.Language, strings.Join(lang.Extensions, ","), lang.Files,
+			formatBytes(lang.Size), lang.Lines, lang.EstimatedTokens)
+	}
+
+	fmt.Fprintf(w, "\nEstimated prompt tokens: ~%d (%d chars per token)\n",
+		report.EstimatedTokens, charsPerToken)
+}
+
+// formatBytes renders a byte count using binary units
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"testing"
+
+	"scanr/internal/fs"
+)
+
+func TestBuildStats(t *testing.T) {
+	files := []fs.FileInfo{
+		{Path: "/p/main.go", Size: 400, Lines: 20, Languages: "go"},
+		{Path: "/p/util.go", Size: 800, Lines: 40, Languages: "go"},
+		{Path: "/p/app.py", Size: 200, Lines: 10, Languages: "python"},
+	}
+
+	report := buildStats(files, []string{"go", "python", "java"})
+
+	if report.TotalFiles != 3 {
+		t.Errorf("TotalFiles = %d, want 3", report.TotalFiles)
+	}
+	if report.TotalSize != 1400 {
+		t.Errorf("TotalSize = %d, want 1400", report.TotalSize)
+	}
+	if report.MaxSize != 800 || report.MinSize != 200 {
+		t.Errorf("size range = %d-%d, want 200-800", report.MinSize, report.MaxSize)
+	}
+	if report.MaxLines != 40 {
+		t.Errorf("MaxLines = %d, want 40", report.MaxLines)
+	}
+	if report.AvgLines != 70.0/3.0 {
+		t.Errorf("AvgLines = %v, want %v", report.AvgLines, 70.0/3.0)
+	}
+	if report.EstimatedTokens != 350 {
+		t.Errorf("EstimatedTokens = %d, want 350", report.EstimatedTokens)
+	}
+
+	if len(report.Languages) != 3 {
+		t.Fatalf("expected 3 language rows, got %d", len(report.Languages))
+	}
+
+	// Sorted by file count, then name
+	want := []struct {
+		lang  string
+		files int
+	}{{"go", 2}, {"python", 1}, {"java", 0}}
+	for i, w := range want {
+		if report.Languages[i].Language != w.lang || report.Languages[i].Files != w.files {
+			t.Errorf("language[%d] = %s/%d, want %s/%d", i,
+				report.Languages[i].Language, report.Languages[i].Files, w.lang, w.files)
+		}
+	}
+}
+
+func TestBuildStats_Empty(t *testing.T) {
+	report := buildStats(nil, []string{"go"})
+
+	if report.TotalFiles != 0 || report.AvgSize != 0 || report.MinSize != 0 {
+		t.Errorf("expected zero-valued report, got %+v", report)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1024, "1.0 KB"},
+		{1536, "1.5 KB"},
+		{1024 * 1024, "1.0 MB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.size); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
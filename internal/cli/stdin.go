@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+
+	"scanr/internal/config"
+	"scanr/internal/fs"
+)
+
+// allLanguages returns every selectable language key
+func allLanguages() []string {
+	languages := make([]string, 0, len(LanguageList))
+	for _, lang := range LanguageList {
+		languages = append(languages, lang.key)
+	}
+	return languages
+}
+
+// readFileList reads newline-separated paths and converts those that exist,
+// live under root and pass the file filter into FileInfo
+func readFileList(r io.Reader, root string, languages []string, cfg *config.Config) ([]fs.FileInfo, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve root: %v", err)
+	}
+
+	filter, err := newFileFilter(root, languages, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []fs.FileInfo
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		path := line
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+		path = filepath.Clean(path)
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			slog.Warn("skipping path outside root", slog.String("path", line))
+			continue
+		}
+
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		file, reason := filter.filter(path, relPath)
+		if reason == "missing" {
+			slog.Warn("skipping missing file", slog.String("path", line))
+			continue
+		}
+		if reason != "" {
+			slog.Debug("skipping file", slog.String("path", line), slog.String("reason", reason))
+			continue
+		}
+		files = append(files, file)
+
+		if cfg.MaxFiles > 0 && len(files) >= cfg.MaxFiles {
+			break
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file list: %v", err)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scanr/internal/config"
+)
+
+func TestReadFileList(t *testing.T) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"main.go":       "package main\n\nfunc main() {}\n",
+		"pkg/util.go":   "package pkg\n",
+		"README.md":     "# readme\n",
+		"script/app.py": "print('hi')\n",
+	} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	outside := filepath.Join(t.TempDir(), "other.go")
+	if err := os.WriteFile(outside, []byte("package other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	input := strings.Join([]string{
+		"main.go",
+		"",
+		filepath.Join(root, "pkg/util.go"),
+		"main.go",       // duplicate
+		"README.md",     // unsupported language
+		"script/app.py", // language not selected
+		"deleted.go",    // missing
+		"../escape.go",  // outside root
+		outside,         // absolute path outside root
+	}, "\n")
+
+	files, err := readFileList(strings.NewReader(input), root, []string{"go"}, &config.Config{})
+	if err != nil {
+		t.Fatalf("readFileList failed: %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d: %+v", len(files), files)
+	}
+	if files[0].Relative != "main.go" || files[0].Lines != 3 || files[0].Languages != "go" {
+		t.Errorf("unexpected first file: %+v", files[0])
+	}
+	if files[1].Relative != filepath.Join("pkg", "util.go") {
+		t.Errorf("unexpected second file: %+v", files[1])
+	}
+}
+
+func TestReadFileList_MaxFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := readFileList(strings.NewReader("a.go\nb.go\nc.go\n"), root, []string{"go"}, &config.Config{MaxFiles: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("expected 2 files, got %d", len(files))
+	}
+}
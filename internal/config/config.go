@@ -2,16 +2,56 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"scanr/internal/fs"
 	"scanr/internal/git"
+	"scanr/internal/review"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 type Config struct {
-	Languages  string
-	StagedOnly bool
-	MaxFiles   int
-	Format     string
+	Languages         string
+	StagedOnly        bool
+	MaxFiles          int
+	MaxFileSize       int64         // bytes; larger files are skipped
+	MaxLines          int           // files with more lines are skipped
+	Workers           int           // files reviewed concurrently
+	QueueSize         int           // files waiting for a worker
+	PerFileTimeout    time.Duration // fixed timeout for every file; zero scales with TimeoutPerLine
+	TimeoutPerLine    time.Duration // timeout per line of a file; zero uses the pipeline default
+	Format            string
+	CSVSeparator      string // CSV field separator; empty means a comma
+	CSVNoHeader       bool   // leave out the CSV header row
+	DefaultConfidence float64
+	MinConfidence     float64
+	IgnoreFile        string
+	ExcludePaths      []string // globs of files never reviewed, relative to the repository root
+	Stream            bool
+	ShowSuppressed    bool
+	ShowFixes         bool
+	PersistFailures   bool   // record failed files under the cache directory as they fail
+	DumpFailures      string // file to write the files still failing after retries to
+	Progress          bool
+	Stdin             bool
+	DryRun            bool
+	ContextLines      int
+	FocusAreas        []string
+	SkipTestFiles     bool
+	IncludeGenerated  bool
+	Wrap              int    // text output width; negative detects the terminal, zero disables
+	Color             string // auto, always or never; empty means auto
+	GroupBy           string // file or directory; empty means file
+	Output            string // report file; empty or "-" writes to stdout
+	Since             string // git ref; review files changed on HEAD since then instead of StagedOnly
+	Branch            string // branch reviewed in full in a bare repository; empty means HEAD
+	IncludeUntracked  bool   // review untracked files too when StagedOnly is off
+	// Paths restricts the review to these files, directories or globs and
+	// overrides StagedOnly
+	Paths []string
 }
 
 type ReviewOptions struct {
@@ -28,8 +68,17 @@ type ReviewOptions struct {
 func ValidateConfig(cfg *Config) error {
 	// Validate format
 	format := strings.ToLower(cfg.Format)
-	if format != "text" && format != "json" {
-		return fmt.Errorf("format must be 'text' or 'json', got %q", cfg.Format)
+	switch format {
+	case "text", "json", "checkstyle", "gitlab", "html", "markdown", "csv":
+	default:
+		return fmt.Errorf("format must be one of text, json, checkstyle, gitlab, html, markdown or csv, got %q", cfg.Format)
+	}
+
+	// Validate CSV separator
+	if cfg.CSVSeparator != "" {
+		if _, err := ParseCSVSeparator(cfg.CSVSeparator); err != nil {
+			return err
+		}
 	}
 
 	// Validate max files
@@ -37,5 +86,125 @@ func ValidateConfig(cfg *Config) error {
 		return fmt.Errorf("max-files must be positive, got %d", cfg.MaxFiles)
 	}
 
+	// Validate file limits
+	if cfg.MaxFileSize <= 0 {
+		return fmt.Errorf("max-file-size must be positive, got %d", cfg.MaxFileSize)
+	}
+	if cfg.MaxLines <= 0 {
+		return fmt.Errorf("max-lines must be positive, got %d", cfg.MaxLines)
+	}
+
+	// Validate concurrency
+	if cfg.Workers <= 0 {
+		return fmt.Errorf("workers must be positive, got %d", cfg.Workers)
+	}
+	if cfg.QueueSize <= 0 {
+		return fmt.Errorf("queue-size must be positive, got %d", cfg.QueueSize)
+	}
+
+	if cfg.PerFileTimeout < 0 {
+		return fmt.Errorf("per-file-timeout cannot be negative, got %s", cfg.PerFileTimeout)
+	}
+	if cfg.TimeoutPerLine < 0 {
+		return fmt.Errorf("timeout-per-line cannot be negative, got %s", cfg.TimeoutPerLine)
+	}
+
+	// Validate default confidence
+	if cfg.DefaultConfidence <= 0 || cfg.DefaultConfidence > 1 {
+		return fmt.Errorf("default-confidence must be greater than 0 and at most 1, got %g", cfg.DefaultConfidence)
+	}
+
+	// Validate min confidence
+	if cfg.MinConfidence < 0 || cfg.MinConfidence > 1 {
+		return fmt.Errorf("min-confidence must be between 0 and 1, got %g", cfg.MinConfidence)
+	}
+
+	// Validate color mode
+	switch strings.ToLower(cfg.Color) {
+	case "", "auto", "always", "never":
+	default:
+		return fmt.Errorf("color must be 'auto', 'always' or 'never', got %q", cfg.Color)
+	}
+
+	// Validate focus areas
+	for _, area := range cfg.FocusAreas {
+		if !slices.Contains(review.Categories, strings.ToLower(area)) {
+			return fmt.Errorf("unknown category %q, must be one of %s", area, strings.Join(review.Categories, ", "))
+		}
+	}
+
+	// Validate grouping
+	switch strings.ToLower(cfg.GroupBy) {
+	case "", "file", "directory":
+	default:
+		return fmt.Errorf("group-by must be 'file' or 'directory', got %q", cfg.GroupBy)
+	}
+	// Streamed output writes each file as it finishes, before its directory is complete
+	if cfg.Stream && strings.EqualFold(cfg.GroupBy, "directory") {
+		return fmt.Errorf("group-by directory cannot be used with --stream")
+	}
+
+	// Validate context lines
+	if cfg.ContextLines < 0 {
+		return fmt.Errorf("context-lines cannot be negative, got %d", cfg.ContextLines)
+	}
+
+	// Files come either from stdin or from arguments
+	if cfg.Stdin && len(cfg.Paths) > 0 {
+		return fmt.Errorf("--stdin cannot be combined with path arguments")
+	}
+	if cfg.Since != "" && (cfg.Stdin || len(cfg.Paths) > 0) {
+		return fmt.Errorf("--since cannot be combined with --stdin or path arguments")
+	}
+
 	return nil
 }
+
+// ParseByteSize parses a size in bytes with an optional k, m or g suffix
+// (binary multiples, an optional trailing "b" is accepted), e.g. "512k"
+func ParseByteSize(value string) (int64, error) {
+	s := strings.ToLower(strings.TrimSpace(value))
+	s = strings.TrimSuffix(s, "b")
+
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(s, "k"):
+		multiplier = 1024
+	case strings.HasSuffix(s, "m"):
+		multiplier = 1024 * 1024
+	case strings.HasSuffix(s, "g"):
+		multiplier = 1024 * 1024 * 1024
+	}
+	if multiplier > 1 {
+		s = s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: use bytes or a k, m or g suffix such as 512k", value)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("invalid size %q: must not be negative", value)
+	}
+	if n > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("invalid size %q: too large", value)
+	}
+	return n * multiplier, nil
+}
+
+// ParseCSVSeparator parses a CSV field separator: a single character, or
+// "tab" or `\t` for a tab. Empty means a comma.
+func ParseCSVSeparator(value string) (rune, error) {
+	switch value {
+	case "":
+		return ',', nil
+	case "tab", `\t`:
+		return '\t', nil
+	}
+
+	r, size := utf8.DecodeRuneInString(value)
+	if size != len(value) || r == utf8.RuneError || r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("csv-separator must be a single character other than a quote or newline, got %q", value)
+	}
+	return r, nil
+}
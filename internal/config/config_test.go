@@ -0,0 +1,109 @@
+package config
+
+import "testing"
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"2048", 2048, false},
+		{"512k", 512 * 1024, false},
+		{"512KB", 512 * 1024, false},
+		{"1m", 1024 * 1024, false},
+		{" 2M ", 2 * 1024 * 1024, false},
+		{"1g", 1024 * 1024 * 1024, false},
+		{"", 0, true},
+		{"k", 0, true},
+		{"1.5m", 0, true},
+		{"ten", 0, true},
+		{"-1", 0, true},
+		{"-512k", 0, true},
+		{"9223372036854775807", 9223372036854775807, false},
+		{"9007199254740992k", 0, true},
+		{"8589934592g", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseByteSize(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseByteSize(%q) = %d, want error", tt.value, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseByteSize(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_Limits(t *testing.T) {
+	base := Config{Format: "text", MaxFiles: 10, MaxFileSize: 1024, MaxLines: 100, Workers: 4, QueueSize: 8, DefaultConfidence: 0.5}
+	if err := ValidateConfig(&base); err != nil {
+		t.Fatalf("valid config rejected: %v", err)
+	}
+
+	noSize := base
+	noSize.MaxFileSize = 0
+	if err := ValidateConfig(&noSize); err == nil {
+		t.Error("expected error for zero max-file-size")
+	}
+
+	noWorkers := base
+	noWorkers.Workers = 0
+	if err := ValidateConfig(&noWorkers); err == nil {
+		t.Error("expected error for zero workers")
+	}
+
+	negativeLines := base
+	negativeLines.MaxLines = -1
+	if err := ValidateConfig(&negativeLines); err == nil {
+		t.Error("expected error for negative max-lines")
+	}
+
+	// Zero would leave unscored issues unscored, not apply a default
+	noConfidence := base
+	noConfidence.DefaultConfidence = 0
+	if err := ValidateConfig(&noConfidence); err == nil {
+		t.Error("expected error for zero default-confidence")
+	}
+}
+
+func TestValidateConfig_GroupByStream(t *testing.T) {
+	base := Config{Format: "text", MaxFiles: 10, MaxFileSize: 1024, MaxLines: 100, Workers: 4, QueueSize: 8, DefaultConfidence: 0.5}
+
+	grouped := base
+	grouped.GroupBy = "directory"
+	if err := ValidateConfig(&grouped); err != nil {
+		t.Errorf("group-by directory rejected: %v", err)
+	}
+
+	streamed := grouped
+	streamed.Stream = true
+	if err := ValidateConfig(&streamed); err == nil {
+		t.Error("expected error for group-by directory with --stream")
+	}
+}
+
+func TestValidateConfig_FocusAreas(t *testing.T) {
+	base := Config{Format: "text", MaxFiles: 10, MaxFileSize: 1024, MaxLines: 100, Workers: 4, QueueSize: 8, DefaultConfidence: 0.5}
+
+	known := base
+	known.FocusAreas = []string{"security", "Reliability"}
+	if err := ValidateConfig(&known); err != nil {
+		t.Errorf("known categories rejected: %v", err)
+	}
+
+	unknown := base
+	unknown.FocusAreas = []string{"security", "speed"}
+	if err := ValidateConfig(&unknown); err == nil {
+		t.Error("expected error for unknown category")
+	}
+}
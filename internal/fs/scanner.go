@@ -2,26 +2,40 @@ package fs
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
 	"strings"
 	"sync"
+
+	"scanr/internal/languages"
 )
 
 // Scans filesysytem for reviewable files
 type Scanner struct {
-	rootDir     string
-	languages   map[string][]string
-	maxFileSize int64
-	maxLines    int
-	ignoreDirs  map[string]bool
-	mu          sync.RWMutex
-	scannedDir  map[string]bool
+	rootDir       string
+	languages     map[string][]string
+	maxFileSize   int64
+	maxLines      int
+	ignoreDirs    map[string]bool
+	excludePaths  []string
+	ignoreFile    string
+	skipBinary    bool
+	skipTests     bool
+	skipGenerated bool
+	parallel      bool
+	mu            sync.RWMutex
+	scannedDir    map[string]bool
 }
 
 // Respresents file to be reviewed
@@ -35,11 +49,17 @@ type FileInfo struct {
 
 // Config holds scanner configuration
 type Config struct {
-	RootDir     string
-	Languages   []string
-	MaxFileSize int64
-	MaxLines    int
-	IgnoreDirs  []string
+	RootDir       string
+	Languages     []string
+	MaxFileSize   int64
+	MaxLines      int
+	IgnoreDirs    []string
+	ExcludePaths  []string // gitignore-style globs of files to skip, relative to RootDir; "**" spans directories
+	IgnoreFile    string
+	SkipBinary    bool // skip files with NUL bytes near the start
+	SkipTestFiles bool // skip files matching testFilePatterns
+	SkipGenerated bool // skip files with a generated-code banner or minified content
+	Parallel      bool // read directories concurrently; files arrive in no particular order
 }
 
 // Default configuration
@@ -48,6 +68,26 @@ const (
 	DefaultMaxLines    = 1000
 )
 
+// sniffSize is how much of a file is read to decide whether it is binary or generated
+const sniffSize = 8 * 1024
+
+const (
+	generatedBannerLines = 10   // lines searched for a generator banner
+	minifiedLineLength   = 1000 // lines longer than this mark minified output
+)
+
+// goGeneratedMarker is the standard Go generated-code comment
+var goGeneratedMarker = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// ScanrIgnoreFile is the name of scanr's own ignore file
+const ScanrIgnoreFile = ".scanrignore"
+
+// ignorePattern is a gitignore-style pattern and the directory it is relative to
+type ignorePattern struct {
+	pattern string
+	base    string
+}
+
 var (
 	DefaultIgnoreDirs = []string{
 		".git",
@@ -108,12 +148,18 @@ func NewScanner(cfg Config) (*Scanner, error) {
 	}
 
 	return &Scanner{
-		rootDir:     rootDir,
-		languages:   langExts,
-		maxFileSize: cfg.MaxFileSize,
-		maxLines:    cfg.MaxLines,
-		ignoreDirs:  igonoreDir,
-		scannedDir:  make(map[string]bool),
+		rootDir:       rootDir,
+		languages:     langExts,
+		maxFileSize:   cfg.MaxFileSize,
+		maxLines:      cfg.MaxLines,
+		ignoreDirs:    igonoreDir,
+		excludePaths:  cfg.ExcludePaths,
+		ignoreFile:    cfg.IgnoreFile,
+		skipBinary:    cfg.SkipBinary,
+		skipTests:     cfg.SkipTestFiles,
+		skipGenerated: cfg.SkipGenerated,
+		parallel:      cfg.Parallel,
+		scannedDir:    make(map[string]bool),
 	}, nil
 
 }
@@ -136,36 +182,81 @@ func getLanguageExtensions(languages []string) (map[string][]string, error) {
 }
 
 // SupportedExtensions maps language keys to their file extensions
-var SupportedExtensions = map[string][]string{
-	"go":         {".go"},
-	"java":       {".java"},
-	"typescript": {".ts", ".tsx"},
-	"javascript": {".js", ".jsx", ".mjs", ".cjs"},
-	"python":     {".py"},
-	"csharp":     {".cs"},
-	"dotnet":     {".cs", ".vb", ".fs"},
+var SupportedExtensions = languages.Extensions()
+
+// testFilePatterns maps language keys to base name patterns of test files
+var testFilePatterns = map[string][]string{
+	"go":         {"*_test.go"},
+	"java":       {"*Test.java", "*Tests.java"},
+	"typescript": {"*.spec.ts", "*.test.ts", "*.spec.tsx", "*.test.tsx"},
+	"javascript": {"*.spec.js", "*.test.js", "*.spec.jsx", "*.test.jsx", "*.spec.mjs", "*.test.mjs"},
+	"python":     {"test_*.py", "*_test.py"},
+	"csharp":     {"*Test.cs", "*Tests.cs"},
+	"dotnet":     {"*Test.cs", "*Tests.cs"},
+	"ruby":       {"*_spec.rb", "*_test.rb"},
+}
+
+// IsTestFile reports whether path is a test file by the conventions of language
+func IsTestFile(language, path string) bool {
+	base := filepath.Base(path)
+	for _, pattern := range testFilePatterns[language] {
+		if matched, _ := filepath.Match(pattern, base); matched {
+			return true
+		}
+	}
+	return false
 }
 
 // Scan scans the filesystem for reviewable files
 func (s *Scanner) Scan(ctx context.Context, maxFiles int) ([]FileInfo, error) {
+	out := make(chan *FileInfo)
+	done := make(chan struct{})
+
+	var files []FileInfo
+	go func() {
+		defer close(done)
+		for file := range out {
+			files = append(files, *file)
+		}
+	}()
+
+	err := s.ScanStream(ctx, maxFiles, out)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// ScanStream scans the filesystem and sends each reviewable file on out as
+// soon as it is found, so reviews can start before the scan finishes. out is
+// closed when the scan ends.
+func (s *Scanner) ScanStream(ctx context.Context, maxFiles int, out chan<- *FileInfo) error {
+	defer close(out)
+
 	// Clear scanned directories map for a fresh scan
 	s.mu.Lock()
 	s.scannedDir = make(map[string]bool)
 	s.mu.Unlock()
 
-	// Load .gitignore patterns
-	gitignorePatterns, err := s.loadGitIgnorePatterns()
+	// Load .gitignore and .scanrignore patterns
+	gitPatterns, scanrPatterns, err := s.loadIgnorePatterns()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load .gitignore: %v", err)
+		return fmt.Errorf("failed to load ignore patterns: %v", err)
 	}
 
-	var files []FileInfo
+	found := 0
 	var mu sync.Mutex
 	var scanErr error
 
+	// The git patterns that apply in each directory: its parent's plus those
+	// of its own .gitignore. A directory is always visited before its entries.
+	var dirPatterns sync.Map
+	dirPatterns.Store(s.rootDir, gitPatterns)
+
 	sem := make(chan struct{}, 10)
 
-	err = filepath.WalkDir(s.rootDir, func(path string, d fs.DirEntry, err error) error {
+	visit := func(path string, d fs.DirEntry) error {
 		// Check context cancellation
 		select {
 		case <-ctx.Done():
@@ -173,35 +264,53 @@ func (s *Scanner) Scan(ctx context.Context, maxFiles int) ([]FileInfo, error) {
 		default:
 		}
 
-		if err != nil {
-			return err
-		}
-
 		// Skip directories that should be ignored
 		if d.IsDir() {
-			return s.handleDirectory(path, d)
+			if err := s.handleDirectory(path, d); err != nil {
+				return err
+			}
+
+			// A nested .gitignore applies to its own subtree only
+			if path != s.rootDir {
+				parent, _ := dirPatterns.Load(filepath.Dir(path))
+				// Clip so sibling directories never append into a shared array
+				patterns, err := s.parseGitIgnoreFile(filepath.Join(path, ".gitignore"), path,
+					slices.Clip(parent.([]ignorePattern)))
+				if err != nil && !os.IsNotExist(err) {
+					return err
+				}
+				dirPatterns.Store(path, patterns)
+			}
+			return nil
 		}
 
 		// Check if we've reached the maximum number of files
 		mu.Lock()
-		if len(files) >= maxFiles && maxFiles > 0 {
+		if found >= maxFiles && maxFiles > 0 {
 			mu.Unlock()
 			return fs.SkipAll
 		}
 		mu.Unlock()
 
 		// Check if file should be ignored
-		if s.shouldIgnore(path, gitignorePatterns) {
+		if s.excluded(path) {
+			return nil
+		}
+		patterns, _ := dirPatterns.Load(filepath.Dir(path))
+		if s.shouldIgnore(path, patterns.([]ignorePattern), scanrPatterns) {
 			return nil
 		}
 
-		// Check file extension
-		ext := strings.ToLower(filepath.Ext(path))
-		lang := s.getLanguageForExtension(ext)
+		// Check file extension, name or content
+		lang := s.getLanguageForPath(path)
 		if lang == "" {
 			return nil
 		}
 
+		if s.skipTests && IsTestFile(lang, path) {
+			return nil
+		}
+
 		// Get file info and check size
 		info, err := d.Info()
 		if err != nil {
@@ -214,14 +323,32 @@ func (s *Scanner) Scan(ctx context.Context, maxFiles int) ([]FileInfo, error) {
 		}
 
 		// Process file (with concurrency limit)
-		sem <- struct{}{}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 		go func() {
 			defer func() { <-sem }()
 
+			if s.skipBinary || s.skipGenerated {
+				head, err := readHead(path, sniffSize)
+				if err == nil && s.skipBinary && isBinary(head) {
+					slog.Debug("skipping binary file", slog.String("path", path))
+					return
+				}
+				if err == nil && s.skipGenerated && isGenerated(path, head) {
+					slog.Debug("skipping generated file", slog.String("path", path))
+					return
+				}
+			}
+
 			// Count lines in file
-			lines, err := s.countLines(path)
+			lines, err := s.countLines(ctx, path)
 			if err != nil {
-				// Skip files we can't read
+				if ctx.Err() == nil {
+					slog.Warn("skipping unreadable file", slog.String("path", path), slog.Any("error", err))
+				}
 				return
 			}
 
@@ -245,64 +372,247 @@ func (s *Scanner) Scan(ctx context.Context, maxFiles int) ([]FileInfo, error) {
 			}
 
 			mu.Lock()
-			if len(files) < maxFiles || maxFiles <= 0 {
-				files = append(files, fileInfo)
+			send := found < maxFiles || maxFiles <= 0
+			if send {
+				found++
 			}
 			mu.Unlock()
+
+			if send {
+				select {
+				case out <- &fileInfo:
+				case <-ctx.Done():
+				}
+			}
 		}()
 
 		return nil
-	})
+	}
+
+	if s.parallel {
+		err = parallelWalk(ctx, s.rootDir, visit)
+	} else {
+		err = filepath.WalkDir(s.rootDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			return visit(path, d)
+		})
+	}
 
 	// Wait for all goroutines to complete
 	for i := 0; i < cap(sem); i++ {
 		sem <- struct{}{}
 	}
 
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	if err != nil && !errors.Is(err, fs.SkipAll) {
-		return nil, fmt.Errorf("walk error: %v", err)
+		return fmt.Errorf("walk error: %v", err)
 	}
 
-	if scanErr != nil {
-		return nil, scanErr
+	return scanErr
+}
+
+// parallelWalk visits the tree under root like filepath.WalkDir, but reads
+// directories on a pool of runtime.NumCPU() goroutines fed from a queue.
+// handler is called concurrently, for a directory always before its entries,
+// and in no particular order otherwise. It may return fs.SkipDir for a
+// directory to skip it or fs.SkipAll to end the walk; any other error ends
+// the walk and is returned. Symbolic links are not followed.
+func parallelWalk(ctx context.Context, root string, handler func(path string, d fs.DirEntry) error) error {
+	info, err := os.Lstat(root)
+	if err != nil {
+		return err
+	}
+	if err := handler(root, fs.FileInfoToDirEntry(info)); err != nil {
+		if errors.Is(err, fs.SkipDir) || errors.Is(err, fs.SkipAll) {
+			return nil
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return nil
 	}
 
-	return files, nil
+	var (
+		mu      sync.Mutex
+		cond    = sync.NewCond(&mu)
+		queue   = []string{root}
+		pending = 1 // directories queued or being read
+		stopped bool
+		walkErr error
+	)
+
+	// stop ends the walk; the first error wins
+	stop := func(err error) {
+		mu.Lock()
+		if !stopped {
+			stopped = true
+			walkErr = err
+		}
+		cond.Broadcast()
+		mu.Unlock()
+	}
+	defer context.AfterFunc(ctx, func() { stop(ctx.Err()) })()
+
+	// readDir visits the entries of dir and returns its subdirectories
+	readDir := func(dir string) ([]string, error) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		var subdirs []string
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			err := handler(path, entry)
+			switch {
+			case err == nil:
+				if entry.IsDir() {
+					subdirs = append(subdirs, path)
+				}
+			case errors.Is(err, fs.SkipDir):
+			default:
+				return nil, err
+			}
+		}
+		return subdirs, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				for len(queue) == 0 && pending > 0 && !stopped {
+					cond.Wait()
+				}
+				if stopped || pending == 0 {
+					mu.Unlock()
+					return
+				}
+				// Breadth first: the oldest queued directory goes next
+				dir := queue[0]
+				queue = queue[1:]
+				mu.Unlock()
+
+				subdirs, err := readDir(dir)
+				if errors.Is(err, fs.SkipAll) {
+					stop(nil)
+					return
+				}
+				if err != nil {
+					stop(err)
+					return
+				}
+
+				mu.Lock()
+				queue = append(queue, subdirs...)
+				pending += len(subdirs) - 1
+				cond.Broadcast()
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if walkErr == nil {
+		// The walk may have finished before the cancellation reached it
+		walkErr = ctx.Err()
+	}
+	return walkErr
 }
 
-// loadGitignorePatterns loads and parses .gitignore files
-func (s *Scanner) loadGitIgnorePatterns() ([]string, error) {
-	var patterns []string
+// loadIgnorePatterns loads and parses ignore files for the scan root. Each
+// list is lowest precedence first. The git patterns come from .git/info/exclude
+// and .gitignore files from the outermost directory down to the root; nested
+// .gitignore files are added as the scan reaches them. The scanr patterns come
+// from ~/.scanrignore, the root .scanrignore and finally the configured ignore
+// file, and override the git patterns.
+func (s *Scanner) loadIgnorePatterns() ([]ignorePattern, []ignorePattern, error) {
+	var patterns []ignorePattern
 
 	// Walk up the directory tree to find all .gitignore files
+	var dirs []string
 	dir := s.rootDir
 	for {
-		gitignorePath := filepath.Join(dir, ".gitignore")
-		if newPatterns, err := s.parseGitIgnoreFile(gitignorePath, patterns); err == nil {
-			patterns = newPatterns
-		} else if !os.IsNotExist(err) {
-			return nil, err
-		}
+		dirs = append(dirs, dir)
 		parent := filepath.Dir(dir)
 		if parent == dir {
 			break
 		}
 		dir = parent
 	}
-	// Add patterns from root .gitignore
-	roorGitignore := filepath.Join(s.rootDir, ".gitignore")
-	newPatterns, err := s.parseGitIgnoreFile(roorGitignore, patterns)
-	if err == nil {
-		return newPatterns, nil
+
+	// The repository's exclude file has lower precedence than any .gitignore
+	for _, dir := range dirs {
+		if info, err := os.Stat(filepath.Join(dir, ".git")); err == nil && info.IsDir() {
+			excludePath := filepath.Join(dir, ".git", "info", "exclude")
+			newPatterns, err := s.parseGitIgnoreFile(excludePath, dir, patterns)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, nil, err
+			}
+			patterns = newPatterns
+			break
+		}
+	}
+
+	// Parse from the outermost directory so patterns closer to the root win
+	for i := len(dirs) - 1; i >= 0; i-- {
+		gitignorePath := filepath.Join(dirs[i], ".gitignore")
+		newPatterns, err := s.parseGitIgnoreFile(gitignorePath, dirs[i], patterns)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, nil, err
+		}
+		patterns = newPatterns
+	}
+
+	// .scanrignore files apply to scanr only and override .gitignore
+	scanrPatterns, err := s.loadScanrIgnorePatterns(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return patterns, scanrPatterns, nil
+}
+
+// loadScanrIgnorePatterns appends ~/.scanrignore, the root .scanrignore and
+// the configured ignore file to patterns
+func (s *Scanner) loadScanrIgnorePatterns(patterns []ignorePattern) ([]ignorePattern, error) {
+	var scanrignorePaths []string
+	if home, err := os.UserHomeDir(); err == nil && home != s.rootDir {
+		scanrignorePaths = append(scanrignorePaths, filepath.Join(home, ScanrIgnoreFile))
+	}
+	scanrignorePaths = append(scanrignorePaths, filepath.Join(s.rootDir, ScanrIgnoreFile))
+
+	for _, path := range scanrignorePaths {
+		newPatterns, err := s.parseGitIgnoreFile(path, s.rootDir, patterns)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		patterns = newPatterns
 	}
-	if os.IsNotExist(err) {
-		return patterns, nil
+
+	// An explicitly configured ignore file must exist
+	if s.ignoreFile != "" {
+		newPatterns, err := s.parseGitIgnoreFile(s.ignoreFile, s.rootDir, patterns)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ignore file %s: %v", s.ignoreFile, err)
+		}
+		patterns = newPatterns
 	}
-	return nil, err
+
+	return patterns, nil
 }
 
-// parseGitignoreFile parses a .gitignore file
-func (s *Scanner) parseGitIgnoreFile(path string, existingPatterns []string) ([]string, error) {
+// parseGitignoreFile parses a .gitignore file whose patterns are relative to base
+func (s *Scanner) parseGitIgnoreFile(path, base string, existingPatterns []ignorePattern) ([]ignorePattern, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return existingPatterns, err
@@ -318,53 +628,106 @@ func (s *Scanner) parseGitIgnoreFile(path string, existingPatterns []string) ([]
 			continue
 		}
 
-		//Handles Navigation
-		if strings.HasPrefix(line, "!") {
-			// For now, we'll just skip negated patterns
-			continue
-		}
+		// Negated patterns re-include files matched by earlier patterns
+		negated := strings.HasPrefix(line, "!")
+		line = strings.TrimPrefix(line, "!")
 
-		// Handle diretory patterns ending with /
-		if strings.HasSuffix(line, "/") {
-			line = strings.TrimSuffix(line, "/") + "/*"
+		// Patterns are kept as written and interpreted by matchGlobPattern
+		pattern := line
+		if negated {
+			pattern = "!" + pattern
 		}
-		pattern := strings.ReplaceAll(line, "**/", "*")
-		pattern = strings.ReplaceAll(pattern, "*", "*")
 
-		existingPatterns = append(existingPatterns, pattern)
+		existingPatterns = append(existingPatterns, ignorePattern{pattern: pattern, base: base})
 	}
 
 	if err := scanner.Err(); err != nil {
-		return existingPatterns, fmt.Errorf("error reading .gitignore: %v", err)
+		return existingPatterns, fmt.Errorf("error reading %s: %v", filepath.Base(path), err)
 	}
 	return existingPatterns, nil
 }
 
-// returns the language for a given file extension
-func (s *Scanner) getLanguageForExtension(ext string) string {
-	for lang, exts := range s.languages {
-		for _, e := range exts {
-			if ext == e {
-				return lang
-			}
+// returns the scanned language a file belongs to, or "" for none
+func (s *Scanner) getLanguageForPath(path string) string {
+	return detectLanguage(path, func(key string) bool {
+		_, ok := s.languages[key]
+		return ok
+	})
+}
+
+// DetectLanguage returns which of languages the file at path belongs to, by
+// extension, file name and, for shared formats such as YAML, content. Files
+// without an extension are matched by their #! line. It returns "" when none
+// match.
+func DetectLanguage(path string, langs []string) string {
+	selected := make(map[string]bool, len(langs))
+	for _, lang := range langs {
+		selected[lang] = true
+	}
+	return detectLanguage(path, func(key string) bool { return selected[key] })
+}
+
+// detectLanguage checks the selected languages in languages.All order, so a
+// file matching several resolves to the first. The file is only read when a
+// matching language needs to sniff its content.
+func detectLanguage(path string, selected func(key string) bool) string {
+	name := filepath.Base(path)
+
+	var head []byte
+	sniffed := false
+	sniff := func() []byte {
+		if !sniffed {
+			// An unreadable file matches no sniffed language
+			head, _ = readHead(path, languages.SniffSize)
+			sniffed = true
+		}
+		return head
+	}
+
+	for _, lang := range languages.All {
+		if !selected(lang.Key) || !lang.Matches(name) {
+			continue
+		}
+		if lang.Sniff != nil && !lang.Sniff(sniff()) {
+			continue
+		}
+		return lang.Key
+	}
+
+	// Scripts without an extension are recognised by their #! line
+	if filepath.Ext(name) != "" {
+		return ""
+	}
+	for _, lang := range languages.All {
+		if selected(lang.Key) && len(lang.Shebangs) > 0 && lang.MatchesShebang(sniff()) {
+			return lang.Key
 		}
 	}
 	return ""
 }
 
-// countLines: counts the number of lines in a file
-func (s *Scanner) countLines(path string) (int, error) {
+// countLines: counts the number of lines in a file, stopping early when ctx is cancelled
+func (s *Scanner) countLines(ctx context.Context, path string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 	defer file.Close()
 
 	count := 0
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
+		if count%100 == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
 		count++
-		if s.maxLines > 0 && count >= s.maxLines {
+		if s.maxLines > 0 && count > s.maxLines {
 			break
 		}
 	}
@@ -374,6 +737,70 @@ func (s *Scanner) countLines(path string) (int, error) {
 	return count, nil
 }
 
+// readHead reads up to n bytes from the start of a file
+func readHead(path string, n int) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	head := make([]byte, n)
+	read, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return head[:read], nil
+}
+
+// isBinary reports whether content looks binary; text files never contain NUL bytes
+func isBinary(head []byte) bool {
+	return bytes.IndexByte(head, 0) >= 0
+}
+
+// IsBinaryFile reports whether the file at path looks binary
+func IsBinaryFile(path string) bool {
+	head, err := readHead(path, sniffSize)
+	return err == nil && isBinary(head)
+}
+
+// IsGeneratedFile reports whether the file at path looks generated
+func IsGeneratedFile(path string) bool {
+	head, err := readHead(path, sniffSize)
+	return err == nil && isGenerated(path, head)
+}
+
+// isGenerated looks for generator banners in the first lines of a file and
+// for minified content, which puts a whole bundle on very long lines
+func isGenerated(path string, head []byte) bool {
+	base := strings.ToLower(filepath.Base(path))
+	if strings.Contains(base, ".min.") {
+		return true
+	}
+
+	lines := strings.SplitN(string(head), "\n", generatedBannerLines+1)
+	for i, line := range lines {
+		if len(line) > minifiedLineLength {
+			return true
+		}
+		if i == generatedBannerLines {
+			break
+		}
+
+		line = strings.TrimSpace(line)
+		if goGeneratedMarker.MatchString(line) || strings.Contains(line, "@generated") {
+			return true
+		}
+
+		lower := strings.ToLower(line)
+		if strings.Contains(lower, "<auto-generated") ||
+			(strings.Contains(lower, "generated") && strings.Contains(lower, "do not edit")) {
+			return true
+		}
+	}
+	return false
+}
+
 func countLinesFromReader(r io.Reader) (int, error) {
 	count := 0
 	scanner := bufio.NewScanner(r)
@@ -403,79 +830,147 @@ func (s *Scanner) handleDirectory(path string, d fs.DirEntry) error {
 	return nil
 }
 
-// shouldIgnore checks if a file should be ignored based on .gitignore patterns
-func (s *Scanner) shouldIgnore(path string, patterns []string) bool {
-	relPath, err := filepath.Rel(s.rootDir, path)
+// IgnoreMatcher matches paths against a fixed set of ignore patterns
+type IgnoreMatcher struct {
+	scanner  *Scanner
+	patterns []ignorePattern
+}
+
+// ScanrIgnoreMatcher loads only scanr's own ignore rules (.scanrignore files,
+// the configured ignore file and ExcludePaths). Git already applies
+// .gitignore to the changes it reports, so callers working from git use this
+// matcher.
+func (s *Scanner) ScanrIgnoreMatcher() (*IgnoreMatcher, error) {
+	patterns, err := s.loadScanrIgnorePatterns(nil)
 	if err != nil {
-		return true // Can't get relative path, skip it
+		return nil, err
 	}
+	return &IgnoreMatcher{scanner: s, patterns: patterns}, nil
+}
+
+// Ignored reports whether path, absolute or relative to the scanner root, is ignored
+func (m *IgnoreMatcher) Ignored(path string) bool {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(m.scanner.rootDir, path)
+	}
+	return m.scanner.excluded(path) || m.scanner.shouldIgnore(path, m.patterns)
+}
 
-	// Normalize path separators for consistent matching
+// excluded reports whether an absolute path matches one of ExcludePaths
+func (s *Scanner) excluded(path string) bool {
+	if len(s.excludePaths) == 0 {
+		return false
+	}
+
+	relPath, err := filepath.Rel(s.rootDir, path)
+	if err != nil {
+		return false
+	}
 	relPath = filepath.ToSlash(relPath)
 
-	// Check against .gitignore patterns
-	for _, pattern := range patterns {
-		if pattern == "" {
-			continue
+	for _, pattern := range s.excludePaths {
+		if matchGlobPattern(pattern, relPath) {
+			return true
 		}
+	}
+	return false
+}
 
-		// Handle directory patterns (ending with /)
-		if strings.HasSuffix(pattern, "/") {
-			dirPattern := strings.TrimSuffix(pattern, "/")
-			if strings.HasPrefix(relPath, dirPattern+"/") || relPath == dirPattern {
-				return true
+// shouldIgnore checks if a file should be ignored based on ignore patterns.
+// Each pattern is matched against the path relative to the directory of the
+// file it came from, and only applies to paths beneath that directory. As
+// with git, the last matching pattern decides, so a later negated pattern
+// ("!keep.go") re-includes a file an earlier pattern ignored.
+func (s *Scanner) shouldIgnore(path string, patternSets ...[]ignorePattern) bool {
+	if _, err := filepath.Rel(s.rootDir, path); err != nil {
+		return true // Can't get relative path, skip it
+	}
+
+	ignored := false
+	for _, patterns := range patternSets {
+		for _, p := range patterns {
+			if p.pattern == "" {
+				continue
 			}
-		}
 
-		// Handle patterns with /* (e.g., node_modules/*)
-		if strings.HasSuffix(pattern, "/*") {
-			dirPattern := strings.TrimSuffix(pattern, "/*")
-			if strings.HasPrefix(relPath, dirPattern+"/") {
-				return true
+			relPath, err := filepath.Rel(p.base, path)
+			if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+				continue
 			}
-		}
 
-		// Handle **/ prefix patterns
-		if strings.HasPrefix(pattern, "**/") {
-			suffix := strings.TrimPrefix(pattern, "**/")
-
-			// For patterns like **/temp/*, we want to match paths where temp
-			// appears as a directory component, but not necessarily at the root
-			if strings.HasSuffix(suffix, "/*") {
-				dirName := strings.TrimSuffix(suffix, "/*")
-				// Split path and check if dirName appears as a directory in the path
-				// (but not as the first component for **/ patterns)
-				parts := strings.Split(relPath, "/")
-				for i := 1; i < len(parts)-1; i++ {
-					if parts[i] == dirName {
-						return true
-					}
-				}
-			} else {
-				// Match if any path component matches the suffix
-				parts := strings.Split(relPath, "/")
-				for i := range parts {
-					subPath := strings.Join(parts[i:], "/")
-					if matched, _ := filepath.Match(suffix, subPath); matched {
-						return true
-					}
-				}
+			// Normalize path separators for consistent matching
+			relPath = filepath.ToSlash(relPath)
+
+			negated := strings.HasPrefix(p.pattern, "!")
+			pattern := strings.TrimPrefix(p.pattern, "!")
+
+			if matchGlobPattern(pattern, relPath) {
+				ignored = !negated
 			}
-			continue
 		}
+	}
+
+	return ignored
+}
+
+// matchGlobPattern reports whether a gitignore-style pattern matches a
+// slash-separated relative path. "**" matches zero or more path segments,
+// patterns without a slash match at any depth, a trailing slash matches only
+// directories, and matching a directory matches everything beneath it.
+func matchGlobPattern(pattern, relPath string) bool {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+
+	if strings.Contains(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+	} else {
+		pattern = "**/" + pattern
+	}
+
+	patternParts := strings.Split(pattern, "/")
+	pathParts := strings.Split(relPath, "/")
 
-		// Standard glob matching
-		matched, err := filepath.Match(pattern, relPath)
-		if err == nil && matched {
+	// Try the path itself and each of its parent directories
+	for i := 1; i <= len(pathParts); i++ {
+		if dirOnly && i == len(pathParts) {
+			break
+		}
+		if matchSegments(patternParts, pathParts[:i]) {
 			return true
 		}
+	}
+	return false
+}
 
-		// Also try matching against the base name
-		matched, err = filepath.Match(pattern, filepath.Base(relPath))
-		if err == nil && matched {
-			return true
+// matchSegments matches pattern segments against path segments one by one
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+
+			// A trailing ** matches everything inside, but not the directory itself
+			if len(rest) == 0 {
+				return len(segments) > 0
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(rest, segments[i:]) {
+					return true
+				}
+			}
+			return false
 		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], segments[0]); err != nil || !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
 	}
 
-	return false
+	return len(segments) == 0
 }
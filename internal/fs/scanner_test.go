@@ -3,10 +3,16 @@ package fs
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewScanner(t *testing.T) {
@@ -261,7 +267,58 @@ func TestScanner_ContextCancellation(t *testing.T) {
 	}
 }
 
-func TestGetLanguageForExtension(t *testing.T) {
+func TestScanner_CancelMidScan(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+
+	for i := 0; i < 500; i++ {
+		path := filepath.Join(testDir, fmt.Sprintf("file%d.go", i))
+		content := strings.Repeat("// line\n", 900)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner, err := NewScanner(Config{RootDir: testDir, Languages: []string{"go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	files, err := scanner.Scan(ctx, 0)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Skipf("scan of %d files finished before the deadline", len(files))
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Scan() error = %v, want context.DeadlineExceeded", err)
+	}
+	if files != nil {
+		t.Errorf("cancelled scan returned %d files", len(files))
+	}
+	if elapsed > time.Second {
+		t.Errorf("cancelled scan took %v", elapsed)
+	}
+}
+
+func TestCountLines_Cancelled(t *testing.T) {
+	testFile := filepath.Join(CreateTempTestDir(t), "main.go")
+	if err := os.WriteFile(testFile, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := (&Scanner{}).countLines(ctx, testFile); !errors.Is(err, context.Canceled) {
+		t.Errorf("countLines() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestGetLanguageForPath(t *testing.T) {
 	scanner := &Scanner{
 		languages: map[string][]string{
 			"go":     {".go"},
@@ -270,26 +327,222 @@ func TestGetLanguageForExtension(t *testing.T) {
 	}
 
 	tests := []struct {
-		ext      string
+		path     string
 		expected string
 	}{
-		{".go", "go"},
-		{".py", "python"},
-		{".js", ""},
-		{".", ""},
-		{"", ""},
+		{"main.go", "go"},
+		{"app.py", "python"},
+		{"index.js", ""},
+		{"Makefile.", ""},
+		{"go", ""},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.ext, func(t *testing.T) {
-			result := scanner.getLanguageForExtension(tt.ext)
+		t.Run(tt.path, func(t *testing.T) {
+			result := scanner.getLanguageForPath(tt.path)
 			if result != tt.expected {
-				t.Errorf("getLanguageForExtension(%q) = %q, want %q", tt.ext, result, tt.expected)
+				t.Errorf("getLanguageForPath(%q) = %q, want %q", tt.path, result, tt.expected)
 			}
 		})
 	}
 }
 
+func TestGetLanguageForPath_SharedExtension(t *testing.T) {
+	scanner, err := NewScanner(Config{
+		RootDir:   CreateTempTestDir(t),
+		Languages: []string{"dotnet", "csharp"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Map iteration order varies between calls; the result must not
+	for i := 0; i < 50; i++ {
+		if lang := scanner.getLanguageForPath("Program.cs"); lang != "csharp" {
+			t.Fatalf("iteration %d: .cs resolved to %q, want csharp", i, lang)
+		}
+	}
+	if lang := scanner.getLanguageForPath("Module.vb"); lang != "dotnet" {
+		t.Errorf(".vb resolved to %q, want dotnet", lang)
+	}
+}
+
+func TestScanner_ExcludePaths(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+	CreateTestDirStructure(t, testDir)
+
+	for _, parallel := range []bool{false, true} {
+		scanner, err := NewScanner(Config{
+			RootDir:      testDir,
+			Languages:    []string{"go"},
+			ExcludePaths: []string{"src/**/app.go", "main.go"},
+			Parallel:     parallel,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files, err := scanner.Scan(context.Background(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got []string
+		for _, file := range files {
+			got = append(got, filepath.ToSlash(file.Relative))
+		}
+		sort.Strings(got)
+		// Excluded files go, but the rest of their directory is still reviewed
+		if strings.Join(got, ",") != "src/main/app_test.go" {
+			t.Errorf("parallel=%v: got %v, want src/main/app_test.go", parallel, got)
+		}
+	}
+}
+
+func TestScanner_MaxLines(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+	files := map[string]int{"short.go": 5, "exact.go": 10, "long.go": 50}
+	for name, n := range files {
+		content := "package main\n" + strings.Repeat("// line\n", n-1)
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, parallel := range []bool{false, true} {
+		scanner, err := NewScanner(Config{
+			RootDir:   testDir,
+			Languages: []string{"go"},
+			MaxLines:  10,
+			Parallel:  parallel,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		scanned, err := scanner.Scan(context.Background(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := make(map[string]int)
+		for _, file := range scanned {
+			got[file.Relative] = file.Lines
+		}
+		if _, ok := got["long.go"]; ok {
+			t.Errorf("parallel=%v: long.go is over MaxLines and should be skipped", parallel)
+		}
+		if got["short.go"] != 5 || got["exact.go"] != 10 {
+			t.Errorf("parallel=%v: got line counts %v, want short.go=5 exact.go=10", parallel, got)
+		}
+	}
+}
+
+func TestDetectLanguage_Infrastructure(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"Dockerfile":         "FROM golang:1.25\n",
+		"Dockerfile.prod":    "FROM alpine:3.20\n",
+		"build.dockerfile":   "FROM alpine:3.20\n",
+		"docker-compose.yml": "version: \"3.8\"\nservices:\n  web:\n    image: nginx\n",
+		"deployment.yaml":    "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n",
+		"multi.yaml":         "---\napiVersion: v1\nkind: Service\n",
+		"config.yml":         "log_level: debug\nservices: []\n",
+		"nested-kind.yaml":   "spec:\n  kind: nothing\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	all := []string{"go", "dockerfile", "compose", "kubernetes"}
+	tests := []struct {
+		name      string
+		languages []string
+		expected  string
+	}{
+		{"Dockerfile", all, "dockerfile"},
+		{"Dockerfile.prod", all, "dockerfile"},
+		{"build.dockerfile", all, "dockerfile"},
+		{"docker-compose.yml", all, "compose"},
+		{"deployment.yaml", all, "kubernetes"},
+		{"multi.yaml", all, "kubernetes"},
+		{"config.yml", all, ""},
+		{"nested-kind.yaml", all, ""},
+		// Only selected languages are detected
+		{"deployment.yaml", []string{"compose"}, ""},
+		{"Dockerfile", []string{"go"}, ""},
+		// A missing file matches no sniffed language
+		{"missing.yaml", all, ""},
+	}
+
+	for _, tt := range tests {
+		if got := DetectLanguage(filepath.Join(dir, tt.name), tt.languages); got != tt.expected {
+			t.Errorf("DetectLanguage(%q, %v) = %q, want %q", tt.name, tt.languages, got, tt.expected)
+		}
+	}
+}
+
+func TestDetectLanguage_Shell(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"build.sh":    "echo build\n",
+		"setup.bash":  "echo setup\n",
+		"prompt.zsh":  "echo prompt\n",
+		"deploy":      "#!/bin/bash\nset -euo pipefail\n",
+		"install":     "#!/bin/sh\necho install\n",
+		"release":     "#!/usr/bin/env bash\necho release\n",
+		"env-options": "#!/usr/bin/env -S zsh -f\necho hi\n",
+		"manage":      "#!/usr/bin/env python3\nprint('hi')\n",
+		"notes":       "just some text\n",
+		"script.txt":  "#!/bin/bash\necho txt\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		languages []string
+		expected  string
+	}{
+		{"build.sh", []string{"shell"}, "shell"},
+		{"setup.bash", []string{"shell"}, "shell"},
+		{"prompt.zsh", []string{"shell"}, "shell"},
+		{"deploy", []string{"shell"}, "shell"},
+		{"install", []string{"shell"}, "shell"},
+		{"release", []string{"shell"}, "shell"},
+		{"env-options", []string{"shell"}, "shell"},
+		{"manage", []string{"shell"}, ""},
+		{"notes", []string{"shell"}, ""},
+		// Only files without an extension are sniffed for a #! line
+		{"script.txt", []string{"shell"}, ""},
+		{"deploy", []string{"go"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := DetectLanguage(filepath.Join(dir, tt.name), tt.languages); got != tt.expected {
+			t.Errorf("DetectLanguage(%q, %v) = %q, want %q", tt.name, tt.languages, got, tt.expected)
+		}
+	}
+
+	// The scanner picks up scripts with and without an extension
+	scanner, err := NewScanner(Config{RootDir: dir, Languages: []string{"shell"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanned, err := scanner.Scan(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scanned) != 7 {
+		t.Errorf("scanned %d shell files, want 7", len(scanned))
+	}
+}
+
 func TestCountLines(t *testing.T) {
 	scanner := &Scanner{}
 
@@ -315,7 +568,7 @@ func TestCountLines(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			lines, err := scanner.countLines(testFile)
+			lines, err := scanner.countLines(context.Background(), testFile)
 			if err != nil {
 				t.Errorf("countLines failed: %v", err)
 			}
@@ -327,6 +580,32 @@ func TestCountLines(t *testing.T) {
 	}
 }
 
+func TestCountLines_Unreadable(t *testing.T) {
+	scanner := &Scanner{}
+	testDir := CreateTempTestDir(t)
+
+	if _, err := scanner.countLines(context.Background(), filepath.Join(testDir, "missing.go")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+
+	if os.Geteuid() == 0 {
+		t.Skip("root can read files regardless of permissions")
+	}
+
+	testFile := filepath.Join(testDir, "secret.go")
+	if err := os.WriteFile(testFile, []byte("package secret\n"), 0000); err != nil {
+		t.Fatal(err)
+	}
+
+	lines, err := scanner.countLines(context.Background(), testFile)
+	if !errors.Is(err, os.ErrPermission) {
+		t.Errorf("countLines() error = %v, want permission denied", err)
+	}
+	if lines != 0 {
+		t.Errorf("countLines() = %d for an unreadable file", lines)
+	}
+}
+
 func TestCountLinesFromReader(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -368,11 +647,9 @@ func TestShouldIgnore(t *testing.T) {
 	testDir := CreateTempTestDir(t)
 	scanner := &Scanner{rootDir: testDir}
 
-	patterns := []string{
-		"*.log",
-		"node_modules/*",
-		"dist/",
-		"**/temp/*",
+	var patterns []ignorePattern
+	for _, pattern := range []string{"*.log", "node_modules/*", "dist/", "**/temp/*"} {
+		patterns = append(patterns, ignorePattern{pattern: pattern, base: testDir})
 	}
 
 	tests := []struct {
@@ -385,7 +662,7 @@ func TestShouldIgnore(t *testing.T) {
 		{"src/temp/file.txt", true},
 		{"src/main/app.go", false},
 		{"test.log.txt", false},
-		{"temp/main.go", false},
+		{"temp/main.go", true}, // ** also matches zero directories
 	}
 
 	for _, tt := range tests {
@@ -398,3 +675,555 @@ func TestShouldIgnore(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchGlobPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"**/*.go", "main.go", true},
+		{"**/*.go", "internal/fs/scanner.go", true},
+		{"**/*.go", "internal/fs/scanner.py", false},
+		{"src/**/test", "src/test", true},
+		{"src/**/test", "src/a/b/test", true},
+		{"src/**/test", "src/a/b/test/fixture.go", true},
+		{"src/**/test", "lib/src/test", false},
+		{"src/**/test", "src/testing", false},
+		{"**/node_modules/**", "node_modules/lodash/index.js", true},
+		{"**/node_modules/**", "web/node_modules/react/index.js", true},
+		{"**/node_modules/**", "node_modules", false},
+		{"**/node_modules/**", "src/modules/index.js", false},
+		{"*.log", "logs/app/server.log", true},
+		{"/build", "build/out.go", true},
+		{"/build", "src/build/out.go", false},
+		{"vendor/", "vendor/pkg/lib.go", true},
+		{"vendor/", "vendor", false},
+		{"docs/*.md", "docs/readme.md", true},
+		{"docs/*.md", "docs/api/readme.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			if got := matchGlobPattern(tt.pattern, tt.path); got != tt.want {
+				t.Errorf("matchGlobPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_ScanrIgnore(t *testing.T) {
+	ctx := context.Background()
+	testDir := CreateTempTestDir(t)
+	t.Setenv("HOME", CreateTempTestDir(t))
+
+	files := map[string]string{
+		"main.go":             "package main\n",
+		"fixtures/data.go":    "package fixtures\n",
+		"scripts/keep.py":     "print('keep')\n",
+		"scripts/drop.py":     "print('drop')\n",
+		"generated/types.go":  "package generated\n",
+		".gitignore":          "*.py\n",
+		ScanrIgnoreFile:       "fixtures/\n!keep.py\n",
+		"config/extra.ignore": "generated/\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(testDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner, err := NewScanner(Config{
+		RootDir:    testDir,
+		Languages:  []string{"go", "python"},
+		IgnoreFile: filepath.Join(testDir, "config/extra.ignore"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := scanner.Scan(ctx, 0)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, f := range found {
+		got[filepath.ToSlash(f.Relative)] = true
+	}
+
+	want := map[string]bool{
+		"main.go":            true,
+		"scripts/keep.py":    true,  // re-included by .scanrignore over .gitignore
+		"scripts/drop.py":    false, // ignored by .gitignore
+		"fixtures/data.go":   false, // ignored by .scanrignore
+		"generated/types.go": false, // ignored by --ignore-file
+	}
+	for path, included := range want {
+		if got[path] != included {
+			t.Errorf("%s included = %v, want %v", path, got[path], included)
+		}
+	}
+}
+
+func TestScanner_HomeScanrIgnore(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+	homeDir := CreateTempTestDir(t)
+	t.Setenv("HOME", homeDir)
+
+	if err := os.WriteFile(filepath.Join(homeDir, ScanrIgnoreFile), []byte("*_gen.go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"main.go", "types_gen.go"} {
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner, err := NewScanner(Config{RootDir: testDir, Languages: []string{"go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := scanner.Scan(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(found) != 1 || found[0].Relative != "main.go" {
+		t.Errorf("expected only main.go, got %+v", found)
+	}
+}
+
+func TestScanner_MissingIgnoreFile(t *testing.T) {
+	scanner, err := NewScanner(Config{
+		RootDir:    CreateTempTestDir(t),
+		Languages:  []string{"go"},
+		IgnoreFile: "/nonexistent/.scanrignore",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := scanner.Scan(context.Background(), 0); err == nil {
+		t.Error("expected error for missing ignore file")
+	}
+}
+
+func TestScanner_SkipBinary(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+
+	files := map[string]string{
+		"main.go":     "package main\n\nfunc main() {}\n",
+		"blob.go":     "package main\n\x00\x01\x02\x00garbage\n",
+		"late_nul.go": "package main\n" + strings.Repeat("// padding\n", 1000) + "\x00\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scan := func(skipBinary bool) map[string]bool {
+		t.Helper()
+		scanner, err := NewScanner(Config{
+			RootDir:    testDir,
+			Languages:  []string{"go"},
+			MaxLines:   5000,
+			SkipBinary: skipBinary,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found, err := scanner.Scan(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		names := make(map[string]bool)
+		for _, f := range found {
+			names[f.Relative] = true
+		}
+		return names
+	}
+
+	got := scan(true)
+	if !got["main.go"] || got["blob.go"] {
+		t.Errorf("with SkipBinary found %v, want main.go without blob.go", got)
+	}
+	// Only the first 8KB are sniffed
+	if !got["late_nul.go"] {
+		t.Error("a NUL byte past the sniffed prefix should not exclude the file")
+	}
+
+	if got := scan(false); !got["blob.go"] {
+		t.Errorf("without SkipBinary found %v, want blob.go included", got)
+	}
+}
+
+func TestScanner_SkipTestFiles(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+	CreateTestDirStructure(t, testDir)
+
+	scan := func(skipTests bool) map[string]bool {
+		t.Helper()
+		scanner, err := NewScanner(Config{
+			RootDir:       testDir,
+			Languages:     []string{"go", "python"},
+			SkipTestFiles: skipTests,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found, err := scanner.Scan(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		names := make(map[string]bool)
+		for _, f := range found {
+			names[filepath.ToSlash(f.Relative)] = true
+		}
+		return names
+	}
+
+	if got := scan(false); !got["src/main/app_test.go"] {
+		t.Errorf("test files should be included by default, got %v", got)
+	}
+
+	got := scan(true)
+	if got["src/main/app_test.go"] {
+		t.Error("app_test.go should be skipped")
+	}
+	if !got["src/main/app.go"] || !got["src/main/utils.py"] {
+		t.Errorf("production files missing: %v", got)
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	tests := []struct {
+		language string
+		path     string
+		want     bool
+	}{
+		{"go", "pkg/scanner_test.go", true},
+		{"go", "pkg/scanner.go", false},
+		{"go", "testdata.go", false},
+		{"python", "tests/test_api.py", true},
+		{"python", "api_test.py", true},
+		{"python", "contest.py", false},
+		{"typescript", "src/app.spec.ts", true},
+		{"typescript", "src/app.test.tsx", true},
+		{"typescript", "src/testing.ts", false},
+		{"javascript", "lib/util.test.js", true},
+		{"java", "src/UserServiceTest.java", true},
+		{"ruby", "spec/user_spec.rb", true},
+		{"rust", "src/lib_test.rs", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsTestFile(tt.language, tt.path); got != tt.want {
+			t.Errorf("IsTestFile(%q, %q) = %v, want %v", tt.language, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    bool
+	}{
+		{"go marker", "api.pb.go", "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n", true},
+		{"go marker after license", "mock.go", "// Copyright 2024\n\n// Code generated by mockgen. DO NOT EDIT.\npackage mock\n", true},
+		{"mentions generation only", "a.go", "// Initially generated by a tool, now maintained by hand\npackage a\n", false},
+		{"generated annotation", "schema.ts", "/**\n * @generated\n */\nexport type A = {}\n", true},
+		{"python banner", "models.py", "# This file was automatically generated. DO NOT EDIT.\n", true},
+		{"csharp banner", "Form.Designer.cs", "// <auto-generated />\nclass Form {}\n", true},
+		{"minified name", "bundle.min.js", "var a=1;\n", true},
+		{"minified content", "bundle.js", strings.Repeat("a=1;", 400) + "\n", true},
+		{"handwritten", "main.go", "package main\n\n// generated values are cached\nfunc main() {}\n", false},
+		{"banner too late", "late.go", strings.Repeat("//\n", 20) + "// @generated\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isGenerated(tt.path, []byte(tt.content)); got != tt.want {
+				t.Errorf("isGenerated(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanner_SkipGenerated(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+
+	files := map[string]string{
+		"main.go":   "package main\n\nfunc main() {}\n",
+		"api.pb.go": "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage main\n",
+		"gen.py":    "# @generated by tool\nVALUE = 1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, skip := range []bool{true, false} {
+		scanner, err := NewScanner(Config{
+			RootDir:       testDir,
+			Languages:     []string{"go", "python"},
+			SkipGenerated: skip,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found, err := scanner.Scan(context.Background(), 0)
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		want := 3
+		if skip {
+			want = 1
+		}
+		if len(found) != want {
+			t.Errorf("SkipGenerated=%v found %d files, want %d", skip, len(found), want)
+		}
+	}
+}
+
+func TestScanner_ScanrIgnoreMatcher(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+	if err := os.WriteFile(filepath.Join(testDir, ".gitignore"), []byte("*.gen.go\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(testDir, ScanrIgnoreFile), []byte("fixtures/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := NewScanner(Config{RootDir: testDir, Languages: []string{"go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	matcher, err := scanner.ScanrIgnoreMatcher()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !matcher.Ignored("fixtures/data.go") {
+		t.Error(".scanrignore pattern should apply")
+	}
+	if matcher.Ignored("api.gen.go") {
+		t.Error(".gitignore patterns are left to git")
+	}
+	if !matcher.Ignored(filepath.Join(testDir, "fixtures", "data.go")) {
+		t.Error("absolute paths under the root should match too")
+	}
+}
+
+func TestScanner_NestedGitIgnore(t *testing.T) {
+	ctx := context.Background()
+	testDir := CreateTempTestDir(t)
+	t.Setenv("HOME", CreateTempTestDir(t))
+
+	files := map[string]string{
+		"main.go":                 "package main\n",
+		"fixture_data.go":         "package main\n",
+		"local.go":                "package main\n",
+		"src/local.go":            "package src\n",
+		"tests/fixture_data.go":   "package tests\n",
+		"tests/unit/fixture_a.go": "package unit\n",
+		"tests/helpers.go":        "package tests\n",
+		"tests/.gitignore":        "fixture_*.go\n/helpers.go\n",
+		".git/info/exclude":       "/local.go\n",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(testDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string]bool{
+		"main.go":                 true,
+		"fixture_data.go":         true,  // tests/.gitignore does not apply outside tests/
+		"tests/fixture_data.go":   false, // ignored by tests/.gitignore
+		"tests/unit/fixture_a.go": false, // unanchored patterns match at any depth below
+		"tests/helpers.go":        false, // anchored to the tests/ directory
+		"local.go":                false, // ignored by .git/info/exclude
+		"src/local.go":            true,  // the exclude pattern is anchored to the repository root
+	}
+
+	// Both walks apply each .gitignore to its own subtree
+	for _, parallel := range []bool{false, true} {
+		scanner, err := NewScanner(Config{RootDir: testDir, Languages: []string{"go"}, Parallel: parallel})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := scanner.Scan(ctx, 0)
+		if err != nil {
+			t.Fatalf("Scan (parallel=%v) failed: %v", parallel, err)
+		}
+
+		got := make(map[string]bool)
+		for _, f := range found {
+			got[filepath.ToSlash(f.Relative)] = true
+		}
+
+		for path, included := range want {
+			if got[path] != included {
+				t.Errorf("parallel=%v: %s included = %v, want %v", parallel, path, got[path], included)
+			}
+		}
+	}
+}
+
+func TestScanner_ParallelMatchesSequential(t *testing.T) {
+	ctx := context.Background()
+	testDir := CreateTempTestDir(t)
+	CreateTestDirStructure(t, testDir)
+	createScanTree(t, testDir, 200)
+
+	scan := func(parallel bool, maxFiles int) []string {
+		scanner, err := NewScanner(Config{RootDir: testDir, Languages: []string{"go", "python"}, Parallel: parallel})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found, err := scanner.Scan(ctx, maxFiles)
+		if err != nil {
+			t.Fatalf("Scan (parallel=%v) failed: %v", parallel, err)
+		}
+		paths := make([]string, len(found))
+		for i, f := range found {
+			paths[i] = f.Relative
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	sequential, parallel := scan(false, 0), scan(true, 0)
+	if !reflect.DeepEqual(sequential, parallel) {
+		t.Errorf("parallel scan found %d files, sequential %d", len(parallel), len(sequential))
+	}
+
+	// The file limit ends the parallel walk early too
+	if got := scan(true, 25); len(got) != 25 {
+		t.Errorf("parallel scan with limit 25 found %d files", len(got))
+	}
+}
+
+func TestParallelWalk_Errors(t *testing.T) {
+	if err := parallelWalk(context.Background(), filepath.Join(t.TempDir(), "missing"),
+		func(string, fs.DirEntry) error { return nil }); err == nil {
+		t.Error("expected error for a missing root")
+	}
+
+	testDir := CreateTempTestDir(t)
+	createScanTree(t, testDir, 50)
+
+	// A handler error ends the walk and is returned
+	boom := errors.New("boom")
+	err := parallelWalk(context.Background(), testDir, func(path string, d fs.DirEntry) error {
+		if !d.IsDir() {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("parallelWalk error = %v, want boom", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = parallelWalk(ctx, testDir, func(string, fs.DirEntry) error { return nil })
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("parallelWalk error = %v, want context.Canceled", err)
+	}
+}
+
+// createScanTree writes n small Go files spread over nested directories
+func createScanTree(tb testing.TB, root string, n int) {
+	tb.Helper()
+	for i := 0; i < n; i++ {
+		dir := filepath.Join(root, "tree", fmt.Sprintf("d%d", i%10), fmt.Sprintf("e%d", i%7))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			tb.Fatal(err)
+		}
+		content := fmt.Sprintf("package e\n\nfunc F%d() {}\n", i)
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%d.go", i)), []byte(content), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanner_Scan(b *testing.B) {
+	root := b.TempDir()
+	createScanTree(b, root, 1000)
+	b.Setenv("HOME", b.TempDir())
+
+	for _, parallel := range []bool{false, true} {
+		name := "sequential"
+		if parallel {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			scanner, err := NewScanner(Config{RootDir: root, Languages: []string{"go"}, Parallel: parallel})
+			if err != nil {
+				b.Fatal(err)
+			}
+			for i := 0; i < b.N; i++ {
+				found, err := scanner.Scan(context.Background(), 0)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(found) != 1000 {
+					b.Fatalf("found %d files, want 1000", len(found))
+				}
+			}
+		})
+	}
+}
+
+func TestScanner_ScanStream(t *testing.T) {
+	testDir := CreateTempTestDir(t)
+	for _, name := range []string{"a.go", "b.go", "c.go", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte("package a\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scanner, err := NewScanner(Config{RootDir: testDir, Languages: []string{"go"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := make(chan *FileInfo)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- scanner.ScanStream(context.Background(), 2, out)
+	}()
+
+	// The channel is closed once the scan ends
+	count := 0
+	for file := range out {
+		if file.Languages != "go" {
+			t.Errorf("unexpected file %s", file.Relative)
+		}
+		count++
+	}
+
+	if err := <-errc; err != nil {
+		t.Fatalf("ScanStream failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("streamed %d files, want the max of 2", count)
+	}
+}
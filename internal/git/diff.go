@@ -64,6 +64,36 @@ func (r *Repository) GetDiff(ctx context.Context, path string, opts DiffOptions)
 	return string(output), nil
 }
 
+// GetChangedFilesSince returns the files added, copied, modified or renamed on
+// HEAD since it diverged from ref (git diff <ref>...HEAD)
+func (r *Repository) GetChangedFilesSince(ctx context.Context, ref string) ([]string, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("ref is required")
+	}
+
+	args := []string{"diff", "--name-only", "-z", "--no-color", "--diff-filter=ACMR", ref + "...HEAD", "--"}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git diff %s...HEAD failed: %s", ref, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git diff failed: %v", err)
+	}
+
+	var files []string
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+
+	return files, nil
+}
+
 // GetFileContent returns the content of a file at a specific revision
 func (r *Repository) GetFileContent(ctx context.Context, revision, path string) ([]byte, error) {
 	ref := revision
@@ -117,6 +147,7 @@ func (r *Repository) GetChangedFiles(ctx context.Context, stagedOnly bool) (map[
 		statusOpts.StagedOnly = true
 	}
 	statusOpts.IncludeRenames = true
+	statusOpts.IncludeUntracked = true
 
 	changes, err := r.GetStatus(ctx, statusOpts)
 	if err != nil {
@@ -191,3 +191,57 @@ func TestRepository_GetChangedFiles(t *testing.T) {
 		t.Errorf("expected 3 files total, got %d", len(allFiles))
 	}
 }
+
+func TestRepository_GetChangedFilesSince(t *testing.T) {
+	testDir := setupTestRepository(t)
+	ctx := context.Background()
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = testDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("base.go", "package main\n")
+	write("removed.go", "package main\n")
+	run("add", ".")
+	run("commit", "-m", "Base")
+	run("tag", "base")
+
+	// Changes on the branch: one modified, one added, one deleted
+	write("base.go", "package main\n\nfunc changed() {}\n")
+	write("added.go", "package main\n")
+	run("add", ".")
+	run("rm", "-q", "removed.go")
+	run("commit", "-m", "Branch work")
+
+	// Uncommitted work is not part of the range
+	write("uncommitted.go", "package main\n")
+
+	repo, err := DetectRepository(testDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := repo.GetChangedFilesSince(ctx, "base")
+	if err != nil {
+		t.Fatalf("GetChangedFilesSince failed: %v", err)
+	}
+
+	if strings.Join(files, ",") != "added.go,base.go" {
+		t.Errorf("changed files = %v, want [added.go base.go]", files)
+	}
+
+	if _, err := repo.GetChangedFilesSince(ctx, "no-such-ref"); err == nil {
+		t.Error("expected error for unknown ref")
+	}
+}
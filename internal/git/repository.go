@@ -1,11 +1,15 @@
 package git
 
 import (
+	"archive/tar"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 )
 
 // Git Repository Detection
@@ -88,3 +92,108 @@ func GetRepositoryRoot(startPath string) (string, error) {
 	}
 	return repo.Path, nil
 }
+
+// GetBranchFiles returns every file on branch as an added change, for
+// reviewing a whole tree, such as a bare repository, rather than a diff
+func (r *Repository) GetBranchFiles(ctx context.Context, branch string) ([]FileChange, error) {
+	if branch == "" {
+		branch = "HEAD"
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "ls-tree", "-r", "-z", "--name-only", branch)
+	cmd.Dir = r.Path
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git ls-tree %s failed: %s", branch, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git ls-tree failed: %v", err)
+	}
+
+	var changes []FileChange
+	for _, path := range strings.Split(string(output), "\x00") {
+		if path != "" {
+			changes = append(changes, FileChange{Path: path, ChangeType: ChangeAdded})
+		}
+	}
+
+	return changes, nil
+}
+
+// ExportTree writes the files on ref into dir with git archive, so a bare
+// repository's files can be read from disk
+func (r *Repository) ExportTree(ctx context.Context, ref, dir string) error {
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "archive", "--format=tar", ref)
+	cmd.Dir = r.Path
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("git archive failed: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("git archive failed: %v", err)
+	}
+
+	extractErr := extractTar(stdout, dir)
+	// Drain the rest so git is not blocked writing to a closed pipe
+	io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		return fmt.Errorf("git archive %s failed: %s", ref, strings.TrimSpace(stderr.String()))
+	}
+	return extractErr
+}
+
+// extractTar writes the regular files and directories of a tar stream into dir
+func extractTar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %v", err)
+		}
+
+		// Archive paths come from git, but never write outside dir
+		name := filepath.FromSlash(header.Name)
+		if !filepath.IsLocal(name) {
+			continue
+		}
+		target := filepath.Join(dir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := writeTarFile(tr, target, header.FileInfo().Mode().Perm()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// writeTarFile copies the current tar entry to path
+func writeTarFile(r io.Reader, path string, perm os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -161,7 +161,7 @@ func TestRepository_GetStatus(t *testing.T) {
 	}
 
 	// Test getting all changes
-	changes, err := repo.GetStatus(ctx, StatusOptions{IncludeRenames: true})
+	changes, err := repo.GetStatus(ctx, StatusOptions{IncludeRenames: true, IncludeUntracked: true})
 	if err != nil {
 		t.Fatalf("GetStatus failed: %v", err)
 	}
@@ -279,8 +279,86 @@ func TestRepository_GetAllChanges(t *testing.T) {
 		t.Fatalf("GetAllChanges failed: %v", err)
 	}
 
-	// Should have 3 changes: 2 staged, 1 unstaged
-	if len(changes) != 3 {
-		t.Errorf("expected 3 changes, got %d", len(changes))
+	// Should have 2 changes: the untracked file3.go is excluded by default
+	if len(changes) != 2 {
+		t.Errorf("expected 2 changes, got %d", len(changes))
+	}
+	for _, change := range changes {
+		if change.Path == "file3.go" {
+			t.Error("untracked file3.go should not be in default changes")
+		}
+	}
+
+	withUntracked, err := repo.GetStatus(ctx, StatusOptions{IncludeRenames: true, IncludeUntracked: true})
+	if err != nil {
+		t.Fatalf("GetStatus failed: %v", err)
+	}
+	if len(withUntracked) != 3 {
+		t.Errorf("expected 3 changes with untracked files, got %d", len(withUntracked))
+	}
+}
+
+func TestRepository_BareBranchFiles(t *testing.T) {
+	testDir := setupTestRepository(t)
+	ctx := context.Background()
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(testDir, "pkg"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"main.go":     "package main\n",
+		"pkg/util.go": "package pkg\n",
+	} {
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run(testDir, "add", ".")
+	run(testDir, "commit", "-q", "-m", "Initial")
+
+	bareDir := filepath.Join(t.TempDir(), "remote.git")
+	run(testDir, "clone", "-q", "--bare", testDir, bareDir)
+
+	repo, err := DetectRepository(bareDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !repo.IsBare {
+		t.Fatal("expected bare repository")
+	}
+
+	changes, err := repo.GetBranchFiles(ctx, "HEAD")
+	if err != nil {
+		t.Fatalf("GetBranchFiles failed: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Path != "main.go" || changes[1].Path != "pkg/util.go" {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+	for _, change := range changes {
+		if change.ChangeType != ChangeAdded {
+			t.Errorf("%s: change type %s, want added", change.Path, change.ChangeType)
+		}
+	}
+
+	if _, err := repo.GetBranchFiles(ctx, "no-such-branch"); err == nil {
+		t.Error("expected error for unknown branch")
+	}
+
+	exportDir := t.TempDir()
+	if err := repo.ExportTree(ctx, "HEAD", exportDir); err != nil {
+		t.Fatalf("ExportTree failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(exportDir, "pkg", "util.go"))
+	if err != nil || string(data) != "package pkg\n" {
+		t.Errorf("exported pkg/util.go = %q, %v", data, err)
 	}
 }
@@ -19,6 +19,12 @@ func (r *Repository) GetStatus(ctx context.Context, opts StatusOptions) ([]FileC
 	if opts.IncludeRenames {
 		args = append(args, "--find-renames")
 	}
+	if opts.IncludeUntracked {
+		// List the files inside untracked directories, not just the directory
+		args = append(args, "--untracked-files=all")
+	} else {
+		args = append(args, "--untracked-files=no")
+	}
 
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = r.Path
@@ -135,6 +141,11 @@ func getStage(x, y byte) string {
 
 // determines if a change should be included based on options
 func shouldIncludeChange(x, y byte, opts StatusOptions) bool {
+	// Untracked files were never committed and may be build output or editor
+	// files, so they are only included on request
+	if x == '?' && y == '?' {
+		return opts.IncludeUntracked && !opts.StagedOnly
+	}
 	if !opts.StagedOnly && !opts.UnstagedOnly {
 		return true
 	}
@@ -165,7 +176,7 @@ func (r *Repository) GetUnstagedChanges(ctx context.Context) ([]FileChange, erro
 	})
 }
 
-// returns all changes (staged and unstaged)
+// returns all changes (staged and unstaged), without untracked files
 func (r *Repository) GetAllChanges(ctx context.Context) ([]FileChange, error) {
 	return r.GetStatus(ctx, StatusOptions{
 		IncludeRenames: true,
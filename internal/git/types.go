@@ -57,4 +57,7 @@ type StatusOptions struct {
 	UnstagedOnly   bool
 	IncludeRenames bool
 	Porcelain      bool
+	// IncludeUntracked reports untracked files, as ChangeUnknown, when
+	// StagedOnly is not set
+	IncludeUntracked bool
 }
@@ -0,0 +1,145 @@
+package languages
+
+import (
+	"bytes"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SniffSize is how much of a file is passed to a language's Sniff function
+const SniffSize = 512
+
+// Language is a reviewable language and the files that belong to it
+type Language struct {
+	Key        string
+	Name       string
+	Extensions []string
+	// Filenames matches files by base name, ignoring case, with or without a
+	// suffix: "Dockerfile" also matches "Dockerfile.prod"
+	Filenames []string
+	// Sniff, when set, must accept the first SniffSize bytes of a file for it
+	// to belong to the language. It tells apart formats sharing an extension.
+	Sniff func(head []byte) bool
+	// Shebangs are the interpreters that claim a file without an extension
+	// through its #! line, e.g. "bash" for "#!/usr/bin/env bash"
+	Shebangs []string
+}
+
+var (
+	// kubernetesKind matches the top-level kind of a Kubernetes manifest
+	kubernetesKind = regexp.MustCompile(`(?m)^kind:[ \t]*\S`)
+	// composeServices and composeVersion match top-level Compose file keys
+	composeServices = regexp.MustCompile(`(?m)^services:`)
+	composeVersion  = regexp.MustCompile(`(?m)^version:`)
+)
+
+// All lists every supported language in the order shown to users. Add new
+// languages here; the scanner and CLI both derive their tables from it.
+var All = []Language{
+	{Key: "go", Name: "Go", Extensions: []string{".go"}},
+	{Key: "java", Name: "Java", Extensions: []string{".java"}},
+	{Key: "typescript", Name: "TypeScript", Extensions: []string{".ts", ".tsx"}},
+	{Key: "javascript", Name: "JavaScript", Extensions: []string{".js", ".jsx", ".mjs", ".cjs"}},
+	{Key: "python", Name: "Python", Extensions: []string{".py"}},
+	{Key: "csharp", Name: "C#", Extensions: []string{".cs"}},
+	{Key: "dotnet", Name: ".NET", Extensions: []string{".cs", ".vb", ".fs"}},
+	{Key: "ruby", Name: "Ruby", Extensions: []string{".rb", ".rake", ".gemspec"}},
+	{Key: "rust", Name: "Rust", Extensions: []string{".rs"}},
+	{Key: "dockerfile", Name: "Dockerfile", Extensions: []string{".dockerfile"}, Filenames: []string{"Dockerfile"}},
+	{Key: "compose", Name: "Docker Compose", Extensions: []string{".yaml", ".yml"}, Sniff: isComposeFile},
+	{Key: "kubernetes", Name: "Kubernetes", Extensions: []string{".yaml", ".yml"}, Sniff: isKubernetesManifest},
+	{Key: "shell", Name: "Shell", Extensions: []string{".sh", ".bash", ".zsh"}, Shebangs: []string{"sh", "bash", "zsh"}},
+}
+
+// Matches reports whether a file with the given base name has one of the
+// language's extensions or file names. It does not apply Sniff.
+func (l Language) Matches(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range l.Extensions {
+		if ext == e {
+			return true
+		}
+	}
+
+	lower := strings.ToLower(name)
+	for _, filename := range l.Filenames {
+		filename = strings.ToLower(filename)
+		if lower == filename || strings.HasPrefix(lower, filename+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesShebang reports whether head starts with a #! line naming one of
+// the language's interpreters, directly or through env
+func (l Language) MatchesShebang(head []byte) bool {
+	interpreter := shebangInterpreter(head)
+	if interpreter == "" {
+		return false
+	}
+	for _, shebang := range l.Shebangs {
+		if interpreter == shebang {
+			return true
+		}
+	}
+	return false
+}
+
+// shebangInterpreter returns the base name of the interpreter on a #! line,
+// looking through "env" and its options, or "" without one
+func shebangInterpreter(head []byte) string {
+	line, ok := bytes.CutPrefix(head, []byte("#!"))
+	if !ok {
+		return ""
+	}
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, arg := range fields[1:] {
+			if !strings.HasPrefix(arg, "-") {
+				interpreter = filepath.Base(arg)
+				break
+			}
+		}
+	}
+	return interpreter
+}
+
+// isComposeFile reports whether YAML declares both services and a version
+func isComposeFile(head []byte) bool {
+	return composeServices.Match(head) && composeVersion.Match(head)
+}
+
+// isKubernetesManifest reports whether YAML declares a resource kind
+func isKubernetesManifest(head []byte) bool {
+	return kubernetesKind.Match(head)
+}
+
+// Extensions maps each language key to its file extensions
+func Extensions() map[string][]string {
+	exts := make(map[string][]string, len(All))
+	for _, lang := range All {
+		exts[lang.Key] = lang.Extensions
+	}
+	return exts
+}
+
+// Lookup finds a language by key or display name, ignoring case
+func Lookup(name string) (Language, bool) {
+	for _, lang := range All {
+		if strings.EqualFold(lang.Key, name) || strings.EqualFold(lang.Name, name) {
+			return lang, true
+		}
+	}
+	return Language{}, false
+}
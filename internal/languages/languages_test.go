@@ -0,0 +1,43 @@
+package languages
+
+import "testing"
+
+func TestAll_UniqueKeys(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, lang := range All {
+		if seen[lang.Key] {
+			t.Errorf("duplicate language key %q", lang.Key)
+		}
+		seen[lang.Key] = true
+
+		if lang.Name == "" || len(lang.Extensions) == 0 {
+			t.Errorf("language %q needs a name and at least one extension", lang.Key)
+		}
+		for _, ext := range lang.Extensions {
+			if ext == "" || ext[0] != '.' {
+				t.Errorf("language %q has malformed extension %q", lang.Key, ext)
+			}
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantKey string
+		wantOK  bool
+	}{
+		{"go", "go", true},
+		{"TypeScript", "typescript", true},
+		{"c#", "csharp", true},
+		{".net", "dotnet", true},
+		{"cobol", "", false},
+	}
+
+	for _, tt := range tests {
+		lang, ok := Lookup(tt.name)
+		if ok != tt.wantOK || lang.Key != tt.wantKey {
+			t.Errorf("Lookup(%q) = %q, %v; want %q, %v", tt.name, lang.Key, ok, tt.wantKey, tt.wantOK)
+		}
+	}
+}
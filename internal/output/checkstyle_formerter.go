@@ -0,0 +1,155 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+
+	"scanr/internal/review"
+)
+
+// CheckstyleFormatter formats review results as Checkstyle XML for CI plugins
+type CheckstyleFormatter struct {
+	config Config
+}
+
+// NewCheckstyleFormatter creates a new Checkstyle formatter
+func NewCheckstyleFormatter(config Config) *CheckstyleFormatter {
+	return &CheckstyleFormatter{config: config}
+}
+
+// CheckstyleOutput is the root <checkstyle> element
+type CheckstyleOutput struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []CheckstyleFile `xml:"file"`
+}
+
+// CheckstyleFile groups the errors reported for one file
+type CheckstyleFile struct {
+	XMLName xml.Name          `xml:"file"`
+	Name    string            `xml:"name,attr"`
+	Errors  []CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single issue
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// checkstyleVersion is the Checkstyle format version reported in the output
+const checkstyleVersion = "4.3"
+
+// Format writes review results as a Checkstyle XML document
+func (f *CheckstyleFormatter) Format(result *review.ReviewResult, w io.Writer) error {
+	output := CheckstyleOutput{Version: checkstyleVersion}
+
+	for _, fileReview := range result.FileReviews {
+		if len(fileReview.Issues) == 0 && !f.config.ShowSuccess {
+			continue
+		}
+		output.Files = append(output.Files, f.convertFileReview(&fileReview))
+	}
+
+	sort.Slice(output.Files, func(i, j int) bool {
+		return output.Files[i].Name < output.Files[j].Name
+	})
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return fmt.Errorf("failed to encode checkstyle XML: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// FormatStream writes each <file> element as its review completes
+func (f *CheckstyleFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s<checkstyle version=%q>\n", xml.Header, checkstyleVersion); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("  ", "  ")
+
+	written := false
+	for fileReview := range issues {
+		if len(fileReview.Issues) == 0 && !f.config.ShowSuccess {
+			continue
+		}
+		if err := encoder.Encode(f.convertFileReview(fileReview)); err != nil {
+			return fmt.Errorf("failed to encode checkstyle file: %w", err)
+		}
+		written = true
+	}
+
+	// The encoder separates elements but leaves the last one unterminated
+	closing := "</checkstyle>\n"
+	if written {
+		closing = "\n" + closing
+	}
+	_, err := io.WriteString(w, closing)
+	return err
+}
+
+// convertFileReview converts a file review to a <file> element
+func (f *CheckstyleFormatter) convertFileReview(fileReview *review.FileReview) CheckstyleFile {
+	file := CheckstyleFile{Name: fileReviewPath(fileReview)}
+
+	issues := make([]review.Issue, len(fileReview.Issues))
+	copy(issues, fileReview.Issues)
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	for _, issue := range issues {
+		file.Errors = append(file.Errors, CheckstyleError{
+			Line:     issue.Line,
+			Column:   issue.Column,
+			Severity: checkstyleSeverity(issue.Severity),
+			Message:  checkstyleMessage(issue),
+			Source:   checkstyleSource(issue),
+		})
+	}
+
+	return file
+}
+
+// checkstyleSeverity maps review severities to Checkstyle's error|warning|info
+func checkstyleSeverity(severity review.Severity) string {
+	switch severity {
+	case review.SeverityCritical:
+		return "error"
+	case review.SeverityHigh:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+// checkstyleMessage combines the issue title and description
+func checkstyleMessage(issue review.Issue) string {
+	if issue.Description == "" {
+		return issue.Title
+	}
+	return issue.Title + ": " + issue.Description
+}
+
+// checkstyleSource returns the rule identifier for an issue
+func checkstyleSource(issue review.Issue) string {
+	if issue.Code != "" {
+		return issue.Code
+	}
+	return "scanr"
+}
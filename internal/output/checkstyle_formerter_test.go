@@ -0,0 +1,91 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scanr/internal/review"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// assertGolden compares output against testdata/name, rewriting it with -update
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output does not match %s\n--- got ---\n%s\n--- want ---\n%s", path, got, want)
+	}
+}
+
+func TestCheckstyleFormatter_Format(t *testing.T) {
+	formatter := NewCheckstyleFormatter(Config{Format: "checkstyle"})
+
+	var buf bytes.Buffer
+	if err := formatter.Format(createTestReviewResult(), &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var output CheckstyleOutput
+	if err := xml.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+
+	assertGolden(t, "checkstyle.golden.xml", buf.Bytes())
+}
+
+func TestCheckstyleFormatter_Stream(t *testing.T) {
+	result := createTestReviewResult()
+	formatter := NewCheckstyleFormatter(Config{Format: "checkstyle"})
+
+	stream := make(chan *review.FileReview, len(result.FileReviews))
+	for i := range result.FileReviews {
+		stream <- &result.FileReviews[i]
+	}
+	close(stream)
+
+	var buf bytes.Buffer
+	if err := formatter.FormatStream(stream, &buf); err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+
+	var output CheckstyleOutput
+	if err := xml.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buf.String())
+	}
+
+	// Streaming produces the same document as Format
+	assertGolden(t, "checkstyle.golden.xml", buf.Bytes())
+}
+
+func TestCheckstyleSeverity(t *testing.T) {
+	tests := []struct {
+		severity review.Severity
+		want     string
+	}{
+		{review.SeverityCritical, "error"},
+		{review.SeverityHigh, "warning"},
+		{review.SeverityInfo, "info"},
+	}
+
+	for _, tt := range tests {
+		if got := checkstyleSeverity(tt.severity); got != tt.want {
+			t.Errorf("checkstyleSeverity(%q) = %q, want %q", tt.severity, got, tt.want)
+		}
+	}
+}
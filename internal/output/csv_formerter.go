@@ -0,0 +1,140 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"scanr/internal/review"
+)
+
+// CSVFormatter formats review results as CSV rows, one per issue, for
+// spreadsheet import
+type CSVFormatter struct {
+	config Config
+}
+
+// NewCSVFormatter creates a new CSV formatter
+func NewCSVFormatter(config Config) *CSVFormatter {
+	return &CSVFormatter{config: config}
+}
+
+// csvHeader names the columns of every row
+var csvHeader = []string{
+	"file", "line", "column", "severity", "category", "code",
+	"title", "description", "confidence", "suggestions",
+}
+
+// Format writes one row per issue, ordered by file and line
+func (f *CSVFormatter) Format(result *review.ReviewResult, w io.Writer) error {
+	fileReviews := make([]*review.FileReview, 0, len(result.FileReviews))
+	for i := range result.FileReviews {
+		fileReviews = append(fileReviews, &result.FileReviews[i])
+	}
+	sort.SliceStable(fileReviews, func(i, j int) bool {
+		return fileReviewPath(fileReviews[i]) < fileReviewPath(fileReviews[j])
+	})
+
+	writer, err := f.newWriter(w)
+	if err != nil {
+		return err
+	}
+	for _, fileReview := range fileReviews {
+		if err := writer.WriteAll(f.convertFileReview(fileReview)); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	return nil
+}
+
+// FormatStream writes the header, then each file's rows as its review completes
+func (f *CSVFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writer) error {
+	writer, err := f.newWriter(w)
+	if err != nil {
+		return err
+	}
+	for fileReview := range issues {
+		if err := writer.WriteAll(f.convertFileReview(fileReview)); err != nil {
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+		flushWriter(w)
+	}
+	return nil
+}
+
+// newWriter creates a CSV writer with the configured separator and writes
+// the header row unless NoHeader is set
+func (f *CSVFormatter) newWriter(w io.Writer) (*csv.Writer, error) {
+	writer := csv.NewWriter(w)
+	if f.config.CSVSeparator != 0 {
+		writer.Comma = f.config.CSVSeparator
+	}
+
+	if !f.config.NoHeader {
+		writer.Write(csvHeader)
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return nil, fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+	return writer, nil
+}
+
+// convertFileReview converts the issues of a file review to rows, by line
+func (f *CSVFormatter) convertFileReview(fileReview *review.FileReview) [][]string {
+	issues := make([]review.Issue, len(fileReview.Issues))
+	copy(issues, fileReview.Issues)
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	file := fileReviewPath(fileReview)
+	rows := make([][]string, 0, len(issues))
+	for _, issue := range issues {
+		name := file
+		if name == "" {
+			name = issue.FilePath
+		}
+		rows = append(rows, []string{
+			csvCell(name),
+			csvNumber(issue.Line),
+			csvNumber(issue.Column),
+			string(issue.Severity),
+			csvCell(issue.Category),
+			csvCell(issue.Code),
+			csvCell(issue.Title),
+			csvCell(issue.Description),
+			csvConfidence(issue.Confidence),
+			csvCell(strings.Join(issue.Suggestions, "|")),
+		})
+	}
+	return rows
+}
+
+// csvNumber leaves unknown (zero) lines and columns empty
+func csvNumber(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// csvConfidence formats a confidence score, leaving unscored issues empty
+func csvConfidence(confidence float64) string {
+	if confidence <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(confidence, 'f', 2, 64)
+}
+
+// csvCell stops spreadsheets from evaluating text that looks like a formula
+// by prefixing it with a quote
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"scanr/internal/review"
+)
+
+func TestCSVFormatter_Format(t *testing.T) {
+	result := createTestReviewResult()
+	result.FileReviews[0].Issues[2].Description = "=HYPERLINK(\"http://example.com\")"
+
+	var buf bytes.Buffer
+	if err := NewCSVFormatter(Config{Format: "csv"}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 6 {
+		t.Fatalf("got %d rows, want a header and 5 issues", len(rows))
+	}
+	if strings.Join(rows[0], ",") != "file,line,column,severity,category,code,title,description,confidence,suggestions" {
+		t.Errorf("unexpected header: %v", rows[0])
+	}
+
+	// Rows are ordered by file, then line
+	var order []string
+	for _, row := range rows[1:] {
+		order = append(order, row[0]+":"+row[1])
+	}
+	if got := strings.Join(order, " "); got != "src/main.go:25 src/main.go:42 src/main.go:55 src/utils.py:15 src/utils.py:20" {
+		t.Errorf("row order = %s", got)
+	}
+
+	first := rows[1]
+	if first[3] != "critical" || first[4] != "security" || first[5] != "SEC001" || first[6] != "Hardcoded API key" || first[8] != "0.90" {
+		t.Errorf("unexpected first row: %v", first)
+	}
+	if !strings.Contains(first[9], "|") {
+		t.Errorf("suggestions should be joined with |, got %q", first[9])
+	}
+
+	// Cells that look like formulas are not evaluated by spreadsheets
+	if got := rows[3][7]; !strings.HasPrefix(got, "'=") {
+		t.Errorf("formula cell = %q, want it quoted", got)
+	}
+}
+
+func TestCSVFormatter_SeparatorAndNoHeader(t *testing.T) {
+	result := createTestReviewResult()
+
+	var buf bytes.Buffer
+	formatter := NewCSVFormatter(Config{Format: "csv", CSVSeparator: ';', NoHeader: true})
+	if err := formatter.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	reader := csv.NewReader(&buf)
+	reader.Comma = ';'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 5 || rows[0][0] != "src/main.go" {
+		t.Errorf("got %d rows starting with %v, want 5 issue rows without a header", len(rows), rows[0])
+	}
+}
+
+func TestCSVFormatter_FormatStream(t *testing.T) {
+	result := createTestReviewResult()
+
+	stream := make(chan *review.FileReview, len(result.FileReviews))
+	for i := len(result.FileReviews) - 1; i >= 0; i-- {
+		stream <- &result.FileReviews[i]
+	}
+	close(stream)
+
+	var buf bytes.Buffer
+	if err := NewCSVFormatter(Config{Format: "csv"}).FormatStream(stream, &buf); err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	// The header comes first, then rows in the order files arrive
+	if len(rows) != 6 || rows[0][0] != "file" || rows[1][0] != "src/utils.py" {
+		t.Errorf("unexpected stream rows: %v", rows)
+	}
+}
@@ -2,12 +2,20 @@ package output
 
 import "scanr/internal/review"
 
+// ExitInterrupted is the exit code of a run stopped by SIGINT or SIGTERM,
+// following the shell convention of 128 + SIGINT
+const ExitInterrupted = 130
+
 // DetermineExitCode returns an exit code based on the review result:
-// 2 = criticals present or nil result, 1 = warnings present, 0 = no issues
+// 130 = interrupted, 2 = criticals present or nil result, 1 = warnings
+// present, 0 = no issues
 func DetermineExitCode(result *review.ReviewResult) int {
 	if result == nil {
 		return 2
 	}
+	if result.Interrupted {
+		return ExitInterrupted
+	}
 	if result.CriticalCount > 0 {
 		return 2
 	}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
 )
 
 // FormatterFactory creates formatters based on configuration
@@ -21,6 +24,16 @@ func (f *FormatterFactory) CreateFormatter(config Config) (Formatter, error) {
 		return NewTextFormatter(config), nil
 	case "json":
 		return NewJSONFormatter(config), nil
+	case "checkstyle":
+		return NewCheckstyleFormatter(config), nil
+	case "gitlab":
+		return NewGitLabFormatter(config), nil
+	case "html":
+		return NewHTMLFormatter(config), nil
+	case "markdown":
+		return NewMarkdownFormatter(config), nil
+	case "csv":
+		return NewCSVFormatter(config), nil
 	case "jsonl":
 		// JSONL is just JSON with streaming
 		config.Format = "json"
@@ -31,20 +44,61 @@ func (f *FormatterFactory) CreateFormatter(config Config) (Formatter, error) {
 }
 
 // CreateFormatterFromFlags creates a formatter from CLI flags
-func (f *FormatterFactory) CreateFormatterFromFlags(format string, color bool) (Formatter, error) {
+func (f *FormatterFactory) CreateFormatterFromFlags(format string, colorMode ColorMode, options ...ConfigOption) (Formatter, error) {
 	config := DefaultConfig()
 	config.Format = format
-	config.Color = color && format == "text" && isTerminal()
+	config.ColorMode = colorMode
+	config.Color = format == "text" && resolveColor(colorMode)
+
+	for _, option := range options {
+		option(&config)
+	}
 
 	return f.CreateFormatter(config)
 }
 
+// DetectCIFormat returns the report format native to the CI system scanr runs
+// in, or "" when none is detected. GitLab sets GITLAB_CI=true in every job.
+func DetectCIFormat() string {
+	if os.Getenv("GITLAB_CI") == "true" {
+		return "gitlab"
+	}
+	return ""
+}
+
+// resolveColor decides whether to color output for a color mode. Auto colors
+// only a terminal and honors the NO_COLOR convention.
+func resolveColor(mode ColorMode) bool {
+	switch mode {
+	case ColorNever:
+		return false
+	case ColorAlways:
+		// The color package disables itself when stdout is not a terminal
+		color.NoColor = false
+		return true
+	default:
+		return os.Getenv("NO_COLOR") == "" && isTerminal()
+	}
+}
+
 // isTerminal checks if stdout is a terminal
 func isTerminal() bool {
 	fileInfo, _ := os.Stdout.Stat()
 	return (fileInfo.Mode() & os.ModeCharDevice) != 0
 }
 
+// defaultTerminalWidth is used when the width of stdout cannot be detected
+const defaultTerminalWidth = 80
+
+// TerminalWidth returns the width of stdout, or 80 when it is not a terminal
+func TerminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return defaultTerminalWidth
+	}
+	return width
+}
+
 // FormatError formats an error for output
 func FormatError(err error, format string) string {
 	switch format {
@@ -11,26 +11,134 @@ type Formatter interface {
 	FormatStream(issues <-chan *review.FileReview, w io.Writer) error
 }
 
+// ColorMode controls when text output is colored
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"
+	ColorAlways ColorMode = "always"
+	ColorNever  ColorMode = "never"
+)
+
 // Config holds output configuration
 type Config struct {
 	Format      string
+	ColorMode   ColorMode
 	Color       bool
 	ShowSuccess bool
+	// GroupBy is "file", or "directory" to group files under their directory
 	GroupBy     string
 	SortBy      string
 	MaxIssues   int
 	SummaryOnly bool
+	// ShowSuppressed reports issues removed by filters separately from the results
+	ShowSuppressed bool
+	// Title is the heading of report formats that have one, such as HTML
+	Title string
+	// ContextLines is the number of source lines shown around each issue in
+	// text output. Zero shows no source.
+	ContextLines int
+	// WrapWidth wraps long text output lines at this many columns. Zero
+	// disables wrapping.
+	WrapWidth int
+	// ShowFixes prints each issue's suggested fix diff in text output
+	ShowFixes bool
+	// CSVSeparator separates CSV fields. Zero uses a comma.
+	CSVSeparator rune
+	// NoHeader leaves out the CSV header row, for appending to a file
+	NoHeader bool
+	// MinConfidence is the confidence filter the results were reviewed
+	// with, for output streamed before the result records it
+	MinConfidence float64
+}
+
+// ConfigOption adjusts an output configuration
+type ConfigOption func(*Config)
+
+// WithShowSuppressed includes suppressed issues in the output
+func WithShowSuppressed(show bool) ConfigOption {
+	return func(c *Config) {
+		c.ShowSuppressed = show
+	}
+}
+
+// WithContextLines sets how many source lines surround each issue
+func WithContextLines(n int) ConfigOption {
+	return func(c *Config) {
+		c.ContextLines = n
+	}
+}
+
+// WithWrapWidth sets the column at which text output is wrapped
+func WithWrapWidth(width int) ConfigOption {
+	return func(c *Config) {
+		c.WrapWidth = width
+	}
+}
+
+// WithShowFixes includes suggested fix diffs in text output
+func WithShowFixes(show bool) ConfigOption {
+	return func(c *Config) {
+		c.ShowFixes = show
+	}
+}
+
+// WithCSVSeparator sets the CSV field separator
+func WithCSVSeparator(separator rune) ConfigOption {
+	return func(c *Config) {
+		c.CSVSeparator = separator
+	}
+}
+
+// WithNoHeader leaves out the CSV header row
+func WithNoHeader(noHeader bool) ConfigOption {
+	return func(c *Config) {
+		c.NoHeader = noHeader
+	}
+}
+
+// WithMinConfidence records the confidence filter applied to the results
+func WithMinConfidence(min float64) ConfigOption {
+	return func(c *Config) {
+		c.MinConfidence = min
+	}
+}
+
+// WithGroupBy sets how issues are grouped: "file" or "directory"
+func WithGroupBy(groupBy string) ConfigOption {
+	return func(c *Config) {
+		if groupBy != "" {
+			c.GroupBy = groupBy
+		}
+	}
 }
 
 // DefaultConfig returns the default output configuration
 func DefaultConfig() Config {
 	return Config{
-		Format:      "text",
-		Color:       true,
-		ShowSuccess: false,
-		GroupBy:     "file",
-		SortBy:      "severity",
-		MaxIssues:   0,
-		SummaryOnly: false,
+		Format:       "text",
+		ColorMode:    ColorAuto,
+		Color:        true,
+		ShowSuccess:  false,
+		GroupBy:      "file",
+		SortBy:       "severity",
+		MaxIssues:    0,
+		SummaryOnly:  false,
+		ContextLines: 2,
+	}
+}
+
+// fileReviewPath returns the path reported for a file review, falling back
+// to the path its issues report when the review has no file info
+func fileReviewPath(fileReview *review.FileReview) string {
+	if fileReview.File == nil {
+		if len(fileReview.Issues) > 0 {
+			return fileReview.Issues[0].FilePath
+		}
+		return ""
+	}
+	if fileReview.File.Relative != "" {
+		return fileReview.File.Relative
 	}
+	return fileReview.File.Path
 }
@@ -0,0 +1,146 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"scanr/internal/review"
+)
+
+// GitLabFormatter formats review results as a GitLab Code Quality report
+type GitLabFormatter struct {
+	config Config
+}
+
+// NewGitLabFormatter creates a new GitLab Code Quality formatter
+func NewGitLabFormatter(config Config) *GitLabFormatter {
+	return &GitLabFormatter{config: config}
+}
+
+// GitLabIssue is a single entry of a Code Quality report
+type GitLabIssue struct {
+	Description string         `json:"description"`
+	CheckName   string         `json:"check_name"`
+	Fingerprint string         `json:"fingerprint"`
+	Severity    string         `json:"severity"`
+	Location    GitLabLocation `json:"location"`
+}
+
+// GitLabLocation points at the file and line of an issue
+type GitLabLocation struct {
+	Path  string      `json:"path"`
+	Lines GitLabLines `json:"lines"`
+}
+
+// GitLabLines holds the first line of an issue
+type GitLabLines struct {
+	Begin int `json:"begin"`
+}
+
+// Format writes review results as a JSON array of Code Quality issues
+func (f *GitLabFormatter) Format(result *review.ReviewResult, w io.Writer) error {
+	issues := []GitLabIssue{}
+	for _, fileReview := range result.FileReviews {
+		issues = append(issues, f.convertFileReview(&fileReview)...)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(issues)
+}
+
+// FormatStream writes the JSON array incrementally as file reviews complete
+func (f *GitLabFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	for fileReview := range issues {
+		for _, issue := range f.convertFileReview(fileReview) {
+			data, err := json.Marshal(issue)
+			if err != nil {
+				return fmt.Errorf("failed to encode GitLab issue: %w", err)
+			}
+
+			separator := ",\n  "
+			if first {
+				separator = "\n  "
+				first = false
+			}
+			if _, err := fmt.Fprintf(w, "%s%s", separator, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	closing := "]\n"
+	if !first {
+		closing = "\n]\n"
+	}
+	_, err := io.WriteString(w, closing)
+	return err
+}
+
+// convertFileReview converts the issues of one file review
+func (f *GitLabFormatter) convertFileReview(fileReview *review.FileReview) []GitLabIssue {
+	path := fileReviewPath(fileReview)
+
+	issues := make([]GitLabIssue, 0, len(fileReview.Issues))
+	for _, issue := range fileReview.Issues {
+		// GitLab requires a positive line number
+		line := issue.Line
+		if line < 1 {
+			line = 1
+		}
+
+		issues = append(issues, GitLabIssue{
+			Description: gitLabDescription(issue),
+			CheckName:   gitLabCheckName(issue),
+			Fingerprint: review.IssueFingerprint(path, issue.Title, issue.Line),
+			Severity:    gitLabSeverity(issue.Severity),
+			Location: GitLabLocation{
+				Path:  path,
+				Lines: GitLabLines{Begin: line},
+			},
+		})
+	}
+
+	return issues
+}
+
+// gitLabSeverity maps review severities to Code Quality severities
+func gitLabSeverity(severity review.Severity) string {
+	switch severity {
+	case review.SeverityCritical:
+		return "critical"
+	case review.SeverityHigh:
+		return "major"
+	case review.SeverityInfo:
+		return "minor"
+	default:
+		return "info"
+	}
+}
+
+// gitLabDescription combines the issue title and description
+func gitLabDescription(issue review.Issue) string {
+	if issue.Description == "" {
+		return issue.Title
+	}
+	return issue.Title + ": " + issue.Description
+}
+
+// gitLabCheckName returns the rule identifier for an issue
+func gitLabCheckName(issue review.Issue) string {
+	if issue.Code != "" {
+		return issue.Code
+	}
+	if issue.Category != "" {
+		return issue.Category
+	}
+	return "scanr"
+}
@@ -0,0 +1,140 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"scanr/internal/review"
+)
+
+// validateGitLabReport checks a report against the fields GitLab's Code
+// Quality widget requires
+func validateGitLabReport(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+
+	var report []map[string]interface{}
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("report must be a JSON array: %v\n%s", err, data)
+	}
+
+	severities := map[string]bool{"info": true, "minor": true, "major": true, "critical": true, "blocker": true}
+	for i, entry := range report {
+		for _, key := range []string{"description", "check_name", "fingerprint", "severity"} {
+			if value, ok := entry[key].(string); !ok || value == "" {
+				t.Errorf("issue %d: %s must be a non-empty string, got %v", i, key, entry[key])
+			}
+		}
+		if severity, _ := entry["severity"].(string); !severities[severity] {
+			t.Errorf("issue %d: invalid severity %q", i, severity)
+		}
+
+		location, ok := entry["location"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("issue %d: location must be an object", i)
+		}
+		if path, ok := location["path"].(string); !ok || path == "" {
+			t.Errorf("issue %d: location.path must be a non-empty string", i)
+		}
+		lines, ok := location["lines"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("issue %d: location.lines must be an object", i)
+		}
+		if begin, ok := lines["begin"].(float64); !ok || begin < 1 {
+			t.Errorf("issue %d: location.lines.begin must be a positive integer, got %v", i, lines["begin"])
+		}
+	}
+
+	return report
+}
+
+func TestGitLabFormatter_Format(t *testing.T) {
+	result := createTestReviewResult()
+	formatter := NewGitLabFormatter(Config{Format: "gitlab"})
+
+	var buf bytes.Buffer
+	if err := formatter.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	report := validateGitLabReport(t, buf.Bytes())
+	if len(report) != 5 {
+		t.Fatalf("expected 5 issues, got %d", len(report))
+	}
+
+	first := report[0]
+	if first["check_name"] != "SEC001" || first["severity"] != "critical" {
+		t.Errorf("unexpected first issue: %v", first)
+	}
+	if first["location"].(map[string]interface{})["path"] != "src/main.go" {
+		t.Errorf("location.path should be relative: %v", first["location"])
+	}
+
+	// Fingerprints are unique and stable between runs
+	seen := make(map[string]bool)
+	for _, entry := range report {
+		fp := entry["fingerprint"].(string)
+		if seen[fp] {
+			t.Errorf("duplicate fingerprint %s", fp)
+		}
+		seen[fp] = true
+	}
+	if first["fingerprint"] != review.IssueFingerprint("src/main.go", "Hardcoded API key", 25) {
+		t.Error("fingerprint does not match review.IssueFingerprint")
+	}
+}
+
+func TestGitLabFormatter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewGitLabFormatter(Config{}).Format(&review.ReviewResult{}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := bytes.TrimSpace(buf.Bytes()); string(got) != "[]" {
+		t.Errorf("empty report = %s, want []", got)
+	}
+}
+
+func TestGitLabFormatter_Stream(t *testing.T) {
+	result := createTestReviewResult()
+	formatter := NewGitLabFormatter(Config{Format: "gitlab"})
+
+	stream := make(chan *review.FileReview, len(result.FileReviews))
+	for i := range result.FileReviews {
+		stream <- &result.FileReviews[i]
+	}
+	close(stream)
+
+	var buf bytes.Buffer
+	if err := formatter.FormatStream(stream, &buf); err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+
+	if report := validateGitLabReport(t, buf.Bytes()); len(report) != 5 {
+		t.Errorf("expected 5 issues, got %d", len(report))
+	}
+}
+
+func TestGitLabSeverity(t *testing.T) {
+	tests := map[review.Severity]string{
+		review.SeverityCritical: "critical",
+		review.SeverityHigh:     "major",
+		review.SeverityInfo:     "minor",
+	}
+	for severity, want := range tests {
+		if got := gitLabSeverity(severity); got != want {
+			t.Errorf("gitLabSeverity(%q) = %q, want %q", severity, got, want)
+		}
+	}
+}
+
+func TestDetectCIFormat(t *testing.T) {
+	t.Setenv("GITLAB_CI", "true")
+	if got := DetectCIFormat(); got != "gitlab" {
+		t.Errorf("DetectCIFormat() with GITLAB_CI=true = %q, want gitlab", got)
+	}
+
+	t.Setenv("GITLAB_CI", "")
+	if got := DetectCIFormat(); got != "" {
+		t.Errorf("DetectCIFormat() outside CI = %q, want none", got)
+	}
+}
@@ -0,0 +1,362 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"scanr/internal/review"
+)
+
+// snippetContext is the number of source lines shown either side of an issue
+const snippetContext = 2
+
+// defaultHTMLTitle is the report heading used when Config.Title is empty
+const defaultHTMLTitle = "scanr Code Review"
+
+// HTMLFormatter formats review results as a self-contained HTML report
+type HTMLFormatter struct {
+	config Config
+	tmpl   *template.Template
+}
+
+// NewHTMLFormatter creates a new HTML formatter
+func NewHTMLFormatter(config Config) *HTMLFormatter {
+	return &HTMLFormatter{
+		config: config,
+		tmpl:   template.Must(template.New("report").Parse(htmlTemplate)),
+	}
+}
+
+// htmlHeader is the data rendered before any file
+type htmlHeader struct {
+	Title string
+	Date  string
+}
+
+// htmlFile is a single file card
+type htmlFile struct {
+	ID       string
+	Path     string
+	Language string
+	Error    string
+	Issues   []htmlIssue
+}
+
+// htmlIssue is a single issue card
+type htmlIssue struct {
+	Line        int
+	Code        string
+	Title       string
+	Description string
+	Severity    string
+	Category    string
+	Suggestions []string
+	Snippet     []htmlSnippetLine
+}
+
+// htmlSnippetLine is one line of source around an issue
+type htmlSnippetLine struct {
+	Number    int
+	Text      string
+	Highlight bool
+}
+
+// htmlFooter is the data rendered after every file
+type htmlFooter struct {
+	Result   *review.ReviewResult
+	Duration string
+	Tree     []htmlTreeDir
+}
+
+// htmlTreeDir groups files by directory for the sidebar
+type htmlTreeDir struct {
+	Dir   string
+	Files []htmlTreeFile
+}
+
+// htmlTreeFile is a sidebar link to a file card
+type htmlTreeFile struct {
+	ID     string
+	Name   string
+	Issues int
+}
+
+// Format writes review results as a complete HTML document
+func (f *HTMLFormatter) Format(result *review.ReviewResult, w io.Writer) error {
+	if err := f.writeHeader(result.StartTime, w); err != nil {
+		return err
+	}
+
+	var files []htmlFile
+	for _, fileReview := range result.FileReviews {
+		if len(fileReview.Issues) == 0 && fileReview.Error == "" && !f.config.ShowSuccess {
+			continue
+		}
+		files = append(files, f.convertFileReview(&fileReview, len(files)))
+	}
+
+	for _, file := range files {
+		if err := f.tmpl.ExecuteTemplate(w, "file", file); err != nil {
+			return fmt.Errorf("failed to render file %s: %w", file.Path, err)
+		}
+	}
+
+	return f.writeFooter(result, files, w)
+}
+
+// FormatStream writes each file card as its review completes and the
+// summary and sidebar once the stream ends
+func (f *HTMLFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writer) error {
+	partial := &review.ReviewResult{StartTime: time.Now()}
+	if err := f.writeHeader(partial.StartTime, w); err != nil {
+		return err
+	}
+
+	var files []htmlFile
+	for fileReview := range issues {
+		accumulateFileReview(partial, fileReview)
+
+		if len(fileReview.Issues) == 0 && fileReview.Error == "" && !f.config.ShowSuccess {
+			continue
+		}
+
+		file := f.convertFileReview(fileReview, len(files))
+		if err := f.tmpl.ExecuteTemplate(w, "file", file); err != nil {
+			return fmt.Errorf("failed to render file %s: %w", file.Path, err)
+		}
+		files = append(files, file)
+	}
+
+	partial.EndTime = time.Now()
+	partial.Duration = partial.EndTime.Sub(partial.StartTime)
+
+	return f.writeFooter(partial, files, w)
+}
+
+// writeHeader opens the document
+func (f *HTMLFormatter) writeHeader(date time.Time, w io.Writer) error {
+	title := f.config.Title
+	if title == "" {
+		title = defaultHTMLTitle
+	}
+
+	header := htmlHeader{Title: title}
+	if !date.IsZero() {
+		header.Date = date.Format(time.RFC1123)
+	}
+
+	if err := f.tmpl.ExecuteTemplate(w, "header", header); err != nil {
+		return fmt.Errorf("failed to render header: %w", err)
+	}
+	return nil
+}
+
+// writeFooter writes the summary dashboard and file tree and closes the document
+func (f *HTMLFormatter) writeFooter(result *review.ReviewResult, files []htmlFile, w io.Writer) error {
+	footer := htmlFooter{
+		Result: result,
+		Tree:   buildHTMLTree(files),
+	}
+	if result.Duration > 0 {
+		footer.Duration = result.Duration.Round(time.Millisecond).String()
+	}
+
+	if err := f.tmpl.ExecuteTemplate(w, "footer", footer); err != nil {
+		return fmt.Errorf("failed to render footer: %w", err)
+	}
+	return nil
+}
+
+// convertFileReview builds a file card, reading source snippets from disk
+func (f *HTMLFormatter) convertFileReview(fileReview *review.FileReview, index int) htmlFile {
+	file := htmlFile{
+		ID:    fmt.Sprintf("file-%d", index),
+		Path:  fileReviewPath(fileReview),
+		Error: fileReview.Error,
+	}
+	if fileReview.File != nil {
+		file.Language = fileReview.File.Languages
+	}
+
+	issues := make([]review.Issue, len(fileReview.Issues))
+	copy(issues, fileReview.Issues)
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Line < issues[j].Line
+	})
+
+	// Source is read once per file; a missing file just means no snippets
+	var source []string
+	if fileReview.File != nil {
+		source, _ = readSourceLines(fileReview.File.Path)
+	}
+
+	for _, issue := range issues {
+		file.Issues = append(file.Issues, htmlIssue{
+			Line:        issue.Line,
+			Code:        issue.Code,
+			Title:       issue.Title,
+			Description: issue.Description,
+			Severity:    string(issue.Severity),
+			Category:    issue.Category,
+			Suggestions: issue.Suggestions,
+			Snippet:     buildSnippet(source, issue.Line, snippetContext),
+		})
+	}
+
+	return file
+}
+
+// readSourceLines reads a file into lines
+func readSourceLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
+
+// buildSnippet returns line (1-based) and up to context lines either side,
+// with the line itself highlighted
+func buildSnippet(source []string, line, context int) []htmlSnippetLine {
+	if line < 1 || line > len(source) {
+		return nil
+	}
+
+	start := line - context
+	if start < 1 {
+		start = 1
+	}
+	end := line + context
+	if end > len(source) {
+		end = len(source)
+	}
+
+	snippet := make([]htmlSnippetLine, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		snippet = append(snippet, htmlSnippetLine{
+			Number:    n,
+			Text:      source[n-1],
+			Highlight: n == line,
+		})
+	}
+	return snippet
+}
+
+// buildHTMLTree groups file cards by directory for the sidebar
+func buildHTMLTree(files []htmlFile) []htmlTreeDir {
+	byDir := make(map[string][]htmlTreeFile)
+	for _, file := range files {
+		dir := filepath.Dir(file.Path)
+		byDir[dir] = append(byDir[dir], htmlTreeFile{
+			ID:     file.ID,
+			Name:   filepath.Base(file.Path),
+			Issues: len(file.Issues),
+		})
+	}
+
+	tree := make([]htmlTreeDir, 0, len(byDir))
+	for dir, dirFiles := range byDir {
+		sort.Slice(dirFiles, func(i, j int) bool {
+			return dirFiles[i].Name < dirFiles[j].Name
+		})
+		tree = append(tree, htmlTreeDir{Dir: dir, Files: dirFiles})
+	}
+	sort.Slice(tree, func(i, j int) bool {
+		return tree[i].Dir < tree[j].Dir
+	})
+
+	return tree
+}
+
+// htmlTemplate renders the report in three parts so it can be streamed.
+// The summary and sidebar are written last and placed with CSS grid.
+const htmlTemplate = `{{define "header"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { margin: 0; font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; color: #24292f; background: #f6f8fa; }
+header { padding: 16px 24px; background: #24292f; color: #fff; }
+header h1 { margin: 0; font-size: 20px; }
+header .date { font-size: 13px; opacity: 0.8; }
+.layout { display: grid; grid-template-columns: 260px 1fr; grid-template-areas: "summary summary" "sidebar files"; gap: 16px; padding: 16px 24px; }
+.summary { grid-area: summary; display: flex; flex-wrap: wrap; gap: 12px; }
+.stat { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: 12px 16px; min-width: 110px; }
+.stat .value { font-size: 24px; font-weight: 600; }
+.stat .label { font-size: 12px; color: #57606a; text-transform: uppercase; }
+.sidebar { grid-area: sidebar; background: #fff; border: 1px solid #d0d7de; border-radius: 6px; padding: 12px; align-self: start; position: sticky; top: 16px; font-size: 13px; }
+.sidebar ul { list-style: none; margin: 4px 0 8px; padding-left: 12px; }
+.sidebar a { color: #0969da; text-decoration: none; }
+.files { grid-area: files; min-width: 0; }
+.file { background: #fff; border: 1px solid #d0d7de; border-radius: 6px; margin-bottom: 16px; }
+.file h2 { margin: 0; padding: 10px 16px; font-size: 15px; border-bottom: 1px solid #d0d7de; background: #f6f8fa; font-family: monospace; }
+.issue { padding: 12px 16px; border-bottom: 1px solid #eaeef2; }
+.issue:last-child { border-bottom: none; }
+.issue h3 { margin: 0 0 6px; font-size: 14px; }
+.issue p { margin: 4px 0; font-size: 13px; }
+.badge { display: inline-block; padding: 1px 8px; border-radius: 10px; font-size: 11px; font-weight: 600; color: #fff; text-transform: uppercase; margin-right: 6px; }
+.badge.critical { background: #cf222e; }
+.badge.warning { background: #bf8700; }
+.badge.info { background: #0969da; }
+.meta { color: #57606a; font-size: 12px; }
+pre.snippet { margin: 8px 0; padding: 8px 0; background: #f6f8fa; border-radius: 4px; overflow-x: auto; font-size: 12px; }
+pre.snippet span { display: block; padding: 0 12px; }
+pre.snippet span.hl { background: #fff8c5; }
+pre.snippet .ln { display: inline-block; width: 40px; color: #8c959f; user-select: none; }
+.error { color: #cf222e; padding: 12px 16px; }
+</style>
+</head>
+<body>
+<header><h1>{{.Title}}</h1>{{if .Date}}<div class="date">{{.Date}}</div>{{end}}</header>
+<div class="layout">
+<main class="files">
+{{end}}
+
+{{define "file"}}<section class="file" id="{{.ID}}">
+<h2>{{.Path}}{{if .Language}} <span class="meta">({{.Language}})</span>{{end}}</h2>
+{{if .Error}}<div class="error">Review failed: {{.Error}}</div>{{end}}
+{{range .Issues}}<div class="issue">
+<h3><span class="badge {{.Severity}}">{{.Severity}}</span>{{.Title}}</h3>
+<div class="meta">Line {{.Line}}{{if .Code}} &middot; {{.Code}}{{end}}{{if .Category}} &middot; {{.Category}}{{end}}</div>
+{{if .Description}}<p>{{.Description}}</p>{{end}}
+{{if .Snippet}}<pre class="snippet">{{range .Snippet}}<span{{if .Highlight}} class="hl"{{end}}><span class="ln">{{.Number}}</span>{{.Text}}</span>{{end}}</pre>{{end}}
+{{if .Suggestions}}<ul>{{range .Suggestions}}<li>{{.}}</li>{{end}}</ul>{{end}}
+</div>
+{{else}}{{if not .Error}}<div class="issue">No issues found</div>{{end}}
+{{end}}</section>
+{{end}}
+
+{{define "footer"}}</main>
+<section class="summary">
+<div class="stat"><div class="value">{{.Result.TotalFiles}}</div><div class="label">Files</div></div>
+<div class="stat"><div class="value">{{.Result.ReviewedFiles}}</div><div class="label">Reviewed</div></div>
+<div class="stat"><div class="value">{{.Result.TotalIssues}}</div><div class="label">Issues</div></div>
+<div class="stat"><div class="value"><span class="badge critical">{{.Result.CriticalCount}}</span></div><div class="label">Critical</div></div>
+<div class="stat"><div class="value"><span class="badge warning">{{.Result.WarningCount}}</span></div><div class="label">Warnings</div></div>
+<div class="stat"><div class="value"><span class="badge info">{{.Result.InfoCount}}</span></div><div class="label">Info</div></div>
+{{if .Duration}}<div class="stat"><div class="value">{{.Duration}}</div><div class="label">Duration</div></div>{{end}}
+</section>
+<nav class="sidebar">
+{{range .Tree}}<div>{{.Dir}}/</div>
+<ul>{{range .Files}}<li><a href="#{{.ID}}">{{.Name}}</a> <span class="meta">({{.Issues}})</span></li>{{end}}</ul>
+{{else}}<div class="meta">No files with issues</div>
+{{end}}</nav>
+</div>
+</body>
+</html>
+{{end}}`
@@ -0,0 +1,138 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"scanr/internal/fs"
+	"scanr/internal/review"
+)
+
+// createHTMLTestResult returns a result whose issue points at a real file
+func createHTMLTestResult(t *testing.T) *review.ReviewResult {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "main.go")
+	source := "package main\n\nfunc main() {\n\tkey := \"<secret>\"\n\t_ = key\n}\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return &review.ReviewResult{
+		TotalFiles:    1,
+		ReviewedFiles: 1,
+		TotalIssues:   1,
+		CriticalCount: 1,
+		FileReviews: []review.FileReview{
+			{
+				File: &fs.FileInfo{Path: path, Relative: "cmd/main.go", Languages: "go"},
+				Issues: []review.Issue{
+					{
+						FilePath:    path,
+						Line:        4,
+						Code:        "SEC001",
+						Title:       "Hardcoded secret <script>",
+						Description: "Move the key to an environment variable",
+						Severity:    review.SeverityCritical,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestHTMLFormatter_Format(t *testing.T) {
+	formatter := NewHTMLFormatter(Config{Format: "html", Title: "Nightly review"})
+
+	var buf bytes.Buffer
+	if err := formatter.Format(createHTMLTestResult(t), &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	output := buf.String()
+
+	checks := []string{
+		"<title>Nightly review</title>",
+		`<section class="file" id="file-0">`,
+		`<span class="badge critical">critical</span>`,
+		`<a href="#file-0">main.go</a>`,
+		// The issue line is highlighted and its neighbours are included
+		`<span class="hl"><span class="ln">4</span>`,
+		`<span class="ln">2</span>`,
+		`<span class="ln">6</span>`,
+		"&lt;secret&gt;",
+		"</html>",
+	}
+	for _, check := range checks {
+		if !strings.Contains(output, check) {
+			t.Errorf("output missing %q", check)
+		}
+	}
+
+	if strings.Contains(output, "<script>") {
+		t.Error("issue text must be HTML-escaped")
+	}
+	if strings.Contains(output, `<link `) || strings.Contains(output, `src="http`) {
+		t.Error("report must not reference external resources")
+	}
+}
+
+func TestHTMLFormatter_DefaultTitle(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewHTMLFormatter(Config{}).Format(&review.ReviewResult{}, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "<title>"+defaultHTMLTitle+"</title>") {
+		t.Error("expected default title")
+	}
+}
+
+func TestHTMLFormatter_Stream(t *testing.T) {
+	result := createHTMLTestResult(t)
+	formatter := NewHTMLFormatter(Config{Format: "html"})
+
+	stream := make(chan *review.FileReview, 1)
+	stream <- &result.FileReviews[0]
+	close(stream)
+
+	var buf bytes.Buffer
+	if err := formatter.FormatStream(stream, &buf); err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, `id="file-0"`) || !strings.HasSuffix(strings.TrimSpace(output), "</html>") {
+		t.Errorf("incomplete streamed document:\n%s", output)
+	}
+	if !strings.Contains(output, `<div class="value">1</div><div class="label">Issues</div>`) {
+		t.Error("summary should count streamed issues")
+	}
+}
+
+func TestBuildSnippet(t *testing.T) {
+	source := []string{"a", "b", "c", "d", "e", "f"}
+
+	tests := []struct {
+		line      int
+		wantFirst int
+		wantLast  int
+	}{
+		{1, 1, 3},
+		{4, 2, 6},
+		{6, 4, 6},
+	}
+
+	for _, tt := range tests {
+		snippet := buildSnippet(source, tt.line, 2)
+		if snippet[0].Number != tt.wantFirst || snippet[len(snippet)-1].Number != tt.wantLast {
+			t.Errorf("line %d: snippet %d-%d, want %d-%d", tt.line,
+				snippet[0].Number, snippet[len(snippet)-1].Number, tt.wantFirst, tt.wantLast)
+		}
+	}
+
+	if buildSnippet(source, 0, 2) != nil || buildSnippet(source, 7, 2) != nil {
+		t.Error("out-of-range lines should have no snippet")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"scanr/internal/fs"
 	"scanr/internal/review"
+	"scanr/internal/version"
 	"sort"
 	"time"
 )
@@ -22,10 +24,12 @@ func NewJSONFormatter(config Config) *JSONFormatter {
 
 // JSONOutput is the structured JSON output format
 type JSONOutput struct {
-	Meta    JSONMeta         `json:"meta"`
-	Summary JSONSummary      `json:"summary"`
-	Results []JSONFileResult `json:"results,omitempty"`
-	Issues  []JSONIssue      `json:"issues,omitempty"`
+	Meta        JSONMeta              `json:"meta"`
+	Summary     JSONSummary           `json:"summary"`
+	Results     []JSONFileResult      `json:"results,omitempty"`
+	Directories []JSONDirectoryResult `json:"directories,omitempty"`
+	Issues      []JSONIssue           `json:"issues,omitempty"`
+	Suppressed  []JSONSuppressedIssue `json:"suppressed,omitempty"`
 }
 
 // JSONMeta contains metadata about the review
@@ -39,13 +43,15 @@ type JSONMeta struct {
 
 // JSONSummary contains review summary statistics
 type JSONSummary struct {
-	TotalFiles    int `json:"total_files"`
-	ReviewedFiles int `json:"reviewed_files"`
-	FailedFiles   int `json:"failed_files"`
-	TotalIssues   int `json:"total_issues"`
-	CriticalCount int `json:"critical_count"`
-	WarningCount  int `json:"warning_count"`
-	InfoCount     int `json:"info_count"`
+	TotalFiles      int  `json:"total_files"`
+	ReviewedFiles   int  `json:"reviewed_files"`
+	FailedFiles     int  `json:"failed_files"`
+	TotalIssues     int  `json:"total_issues"`
+	CriticalCount   int  `json:"critical_count"`
+	WarningCount    int  `json:"warning_count"`
+	InfoCount       int  `json:"info_count"`
+	SuppressedCount int  `json:"suppressed_count,omitempty"`
+	Interrupted     bool `json:"interrupted,omitempty"`
 }
 
 // JSONFileResult contains results for a single file
@@ -56,6 +62,16 @@ type JSONFileResult struct {
 	Error    string       `json:"error,omitempty"`
 }
 
+// JSONDirectoryResult contains the results of the files in one directory
+type JSONDirectoryResult struct {
+	Directory     string           `json:"directory"`
+	TotalIssues   int              `json:"total_issues"`
+	CriticalCount int              `json:"critical_count"`
+	WarningCount  int              `json:"warning_count"`
+	InfoCount     int              `json:"info_count"`
+	Files         []JSONFileResult `json:"files"`
+}
+
 // JSONFileInfo contains file information
 type JSONFileInfo struct {
 	Path     string `json:"path"`
@@ -67,19 +83,26 @@ type JSONFileInfo struct {
 
 // JSONIssue contains a single issue
 type JSONIssue struct {
-	ID          string    `json:"id,omitempty"`
-	FilePath    string    `json:"file_path"`
-	Relative    string    `json:"relative_path,omitempty"`
-	Line        int       `json:"line,omitempty"`
-	Column      int       `json:"column,omitempty"`
-	Code        string    `json:"code,omitempty"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Severity    string    `json:"severity"`
-	Category    string    `json:"category,omitempty"`
-	Suggestions []string  `json:"suggestions,omitempty"`
-	Confidence  float64   `json:"confidence,omitempty"`
-	FoundAt     time.Time `json:"found_at"`
+	ID            string    `json:"id,omitempty"`
+	FilePath      string    `json:"file_path"`
+	Relative      string    `json:"relative_path,omitempty"`
+	Line          int       `json:"line,omitempty"`
+	Column        int       `json:"column,omitempty"`
+	Code          string    `json:"code,omitempty"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Severity      string    `json:"severity"`
+	Category      string    `json:"category,omitempty"`
+	Suggestions   []string  `json:"suggestions,omitempty"`
+	FixSuggestion string    `json:"fix_suggestion,omitempty"`
+	Confidence    float64   `json:"confidence,omitempty"`
+	FoundAt       time.Time `json:"found_at"`
+}
+
+// JSONSuppressedIssue is an issue removed by a filter, with the reason it was hidden
+type JSONSuppressedIssue struct {
+	JSONIssue
+	Reason string `json:"reason"`
 }
 
 // Formats review results as JSON
@@ -93,17 +116,30 @@ func (f *JSONFormatter) Format(result *review.ReviewResult, w io.Writer) error {
 	return encoder.Encode(output)
 }
 
-// Formats streaming review results as JSON Lines (NDJSON)
+// JSONStreamSummary is the final line written after a stream of file results
+type JSONStreamSummary struct {
+	Summary JSONSummary `json:"summary"`
+}
+
+// Formats streaming review results as JSON Lines (NDJSON), ending with a summary line
 func (f *JSONFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writer) error {
 	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
 
+	partial := &review.ReviewResult{}
 	for fileReview := range issues {
+		accumulateFileReview(partial, fileReview)
+
 		jsonResult := f.convertFileReview(fileReview)
 		if err := encoder.Encode(jsonResult); err != nil {
 			return fmt.Errorf("failed to encode JSON line: %w", err)
 		}
 	}
 
+	if err := encoder.Encode(JSONStreamSummary{Summary: f.buildSummary(partial)}); err != nil {
+		return fmt.Errorf("failed to encode JSON summary: %w", err)
+	}
+
 	return nil
 }
 
@@ -111,36 +147,48 @@ func (f *JSONFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writ
 func (f *JSONFormatter) buildJSONOutput(result *review.ReviewResult) JSONOutput {
 	meta := JSONMeta{
 		Tool:      "scanr",
-		Version:   "1.0.0",
+		Version:   version.Get().Version,
 		Timestamp: result.StartTime,
 		Duration:  result.Duration.Seconds() * 1000,
 	}
 
-	summary := JSONSummary{
-		TotalFiles:    result.TotalFiles,
-		ReviewedFiles: result.ReviewedFiles,
-		FailedFiles:   result.TotalFiles - result.ReviewedFiles,
-		TotalIssues:   result.TotalIssues,
-		CriticalCount: result.CriticalCount,
-		WarningCount:  result.WarningCount,
-		InfoCount:     result.InfoCount,
-	}
-
 	output := JSONOutput{
 		Meta:    meta,
-		Summary: summary,
+		Summary: f.buildSummary(result),
 	}
 
 	// Build results based on grouping preference
-	if f.config.GroupBy == "file" || f.config.GroupBy == "" {
+	switch f.config.GroupBy {
+	case "file", "":
 		output.Results = f.buildFileResults(result)
-	} else {
+	case "directory":
+		output.Directories = f.buildDirectoryResults(result)
+	default:
 		output.Issues = f.buildFlatIssues(result)
 	}
 
+	if f.config.ShowSuppressed {
+		output.Suppressed = f.buildSuppressedIssues(result)
+	}
+
 	return output
 }
 
+// buildSummary builds the summary statistics for a result
+func (f *JSONFormatter) buildSummary(result *review.ReviewResult) JSONSummary {
+	return JSONSummary{
+		TotalFiles:      result.TotalFiles,
+		ReviewedFiles:   result.ReviewedFiles,
+		FailedFiles:     result.TotalFiles - result.ReviewedFiles,
+		TotalIssues:     result.TotalIssues,
+		CriticalCount:   result.CriticalCount,
+		WarningCount:    result.WarningCount,
+		InfoCount:       result.InfoCount,
+		SuppressedCount: result.SuppressedCount,
+		Interrupted:     result.Interrupted,
+	}
+}
+
 // buildFileResults builds file-grouped results
 func (f *JSONFormatter) buildFileResults(result *review.ReviewResult) []JSONFileResult {
 	var results []JSONFileResult
@@ -157,13 +205,49 @@ func (f *JSONFormatter) buildFileResults(result *review.ReviewResult) []JSONFile
 	return results
 }
 
+// buildDirectoryResults builds file results grouped by directory, with
+// directories in path order
+func (f *JSONFormatter) buildDirectoryResults(result *review.ReviewResult) []JSONDirectoryResult {
+	var directories []JSONDirectoryResult
+	index := make(map[string]int)
+
+	for _, fileResult := range f.buildFileResults(result) {
+		dir := filepath.Dir(fileResult.File.Relative)
+		i, ok := index[dir]
+		if !ok {
+			i = len(directories)
+			index[dir] = i
+			directories = append(directories, JSONDirectoryResult{Directory: dir})
+		}
+
+		directory := &directories[i]
+		directory.Files = append(directory.Files, fileResult)
+		for _, issue := range fileResult.Issues {
+			directory.TotalIssues++
+			switch review.Severity(issue.Severity) {
+			case review.SeverityCritical:
+				directory.CriticalCount++
+			case review.SeverityHigh:
+				directory.WarningCount++
+			case review.SeverityInfo:
+				directory.InfoCount++
+			}
+		}
+	}
+
+	sort.SliceStable(directories, func(i, j int) bool {
+		return directories[i].Directory < directories[j].Directory
+	})
+	return directories
+}
+
 // buildFlatIssues builds a flat list of issues
 func (f *JSONFormatter) buildFlatIssues(result *review.ReviewResult) []JSONIssue {
 	var issues []JSONIssue
 
 	for _, fileReview := range result.FileReviews {
 		for _, issue := range fileReview.Issues {
-			jsonIssue := f.convertIssue(issue, *fileReview.File)
+			jsonIssue := f.convertIssue(issue, fileReview.File)
 			issues = append(issues, jsonIssue)
 		}
 	}
@@ -179,19 +263,38 @@ func (f *JSONFormatter) buildFlatIssues(result *review.ReviewResult) []JSONIssue
 	return issues
 }
 
+// buildSuppressedIssues lists issues removed by filters across all files
+func (f *JSONFormatter) buildSuppressedIssues(result *review.ReviewResult) []JSONSuppressedIssue {
+	var suppressed []JSONSuppressedIssue
+
+	for _, fileReview := range result.FileReviews {
+		for _, s := range fileReview.Suppressed {
+			suppressed = append(suppressed, JSONSuppressedIssue{
+				JSONIssue: f.convertIssue(s.Issue, fileReview.File),
+				Reason:    s.Reason,
+			})
+		}
+	}
+
+	return suppressed
+}
+
 // convertFileReview converts a FileReview to JSONFileResult
 func (f *JSONFormatter) convertFileReview(fileReview *review.FileReview) JSONFileResult {
-	fileInfo := JSONFileInfo{
-		Path:     fileReview.File.Path,
-		Relative: fileReview.File.Relative,
-		Language: fileReview.File.Languages,
-		Size:     fileReview.File.Size,
-		Lines:    fileReview.File.Lines,
+	var fileInfo JSONFileInfo
+	if fileReview.File != nil {
+		fileInfo = JSONFileInfo{
+			Path:     fileReview.File.Path,
+			Relative: fileReview.File.Relative,
+			Language: fileReview.File.Languages,
+			Size:     fileReview.File.Size,
+			Lines:    fileReview.File.Lines,
+		}
 	}
 
 	var issues []JSONIssue
 	for _, issue := range fileReview.Issues {
-		jsonIssue := f.convertIssue(issue, *fileReview.File)
+		jsonIssue := f.convertIssue(issue, fileReview.File)
 		issues = append(issues, jsonIssue)
 	}
 
@@ -211,20 +314,26 @@ func (f *JSONFormatter) convertFileReview(fileReview *review.FileReview) JSONFil
 }
 
 // convertIssue converts an Issue to JSONIssue
-func (f *JSONFormatter) convertIssue(issue review.Issue, file fs.FileInfo) JSONIssue {
+func (f *JSONFormatter) convertIssue(issue review.Issue, file *fs.FileInfo) JSONIssue {
+	relative := ""
+	if file != nil {
+		relative = file.Relative
+	}
+
 	return JSONIssue{
-		FilePath:    issue.FilePath,
-		Relative:    file.Relative,
-		Line:        issue.Line,
-		Column:      issue.Column,
-		Code:        issue.Code,
-		Title:       issue.Title,
-		Description: issue.Description,
-		Severity:    string(issue.Severity),
-		Category:    issue.Category,
-		Suggestions: issue.Suggestions,
-		Confidence:  issue.Confidence,
-		FoundAt:     issue.FoundAt,
+		FilePath:      issue.FilePath,
+		Relative:      relative,
+		Line:          issue.Line,
+		Column:        issue.Column,
+		Code:          issue.Code,
+		Title:         issue.Title,
+		Description:   issue.Description,
+		Severity:      string(issue.Severity),
+		Category:      issue.Category,
+		Suggestions:   issue.Suggestions,
+		FixSuggestion: issue.FixSuggestion,
+		Confidence:    issue.Confidence,
+		FoundAt:       issue.FoundAt,
 	}
 }
 
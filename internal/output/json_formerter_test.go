@@ -7,6 +7,8 @@ import (
 	"scanr/internal/review"
 	"strings"
 	"testing"
+
+	"github.com/fatih/color"
 )
 
 func TestJSONFormatter_Format(t *testing.T) {
@@ -205,10 +207,10 @@ func TestJSONFormatter_Stream(t *testing.T) {
 		t.Fatalf("FormatStream failed: %v", err)
 	}
 
-	// Each line should be valid JSON
+	// One line per file plus a trailing summary line
 	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
-	if len(lines) != len(result.FileReviews) {
-		t.Errorf("Expected %d JSON lines, got %d", len(result.FileReviews), len(lines))
+	if len(lines) != len(result.FileReviews)+1 {
+		t.Fatalf("Expected %d JSON lines, got %d", len(result.FileReviews)+1, len(lines))
 	}
 
 	for i, line := range lines {
@@ -217,6 +219,113 @@ func TestJSONFormatter_Stream(t *testing.T) {
 			t.Errorf("Line %d is not valid JSON: %v", i, err)
 		}
 	}
+
+	var summary JSONStreamSummary
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("summary line is not valid JSON: %v", err)
+	}
+	if summary.Summary.TotalFiles != len(result.FileReviews) {
+		t.Errorf("summary total files = %d, want %d", summary.Summary.TotalFiles, len(result.FileReviews))
+	}
+	if summary.Summary.TotalIssues != 5 || summary.Summary.CriticalCount != 1 {
+		t.Errorf("summary counts = %+v, want 5 issues with 1 critical", summary.Summary)
+	}
+}
+
+func TestJSONFormatter_Suppressed(t *testing.T) {
+	result := createTestReviewResult()
+	result.FileReviews[0].Suppressed = []review.SuppressedIssue{
+		{
+			Issue: review.Issue{
+				FilePath: "/project/src/main.go",
+				Line:     7,
+				Title:    "Possible typo",
+				Severity: review.SeverityInfo,
+			},
+			Reason: "low confidence",
+		},
+	}
+	result.SuppressedCount = 1
+
+	tests := []struct {
+		name           string
+		showSuppressed bool
+		wantSuppressed int
+	}{
+		{"hidden by default", false, 0},
+		{"shown when enabled", true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatter := NewJSONFormatter(Config{Format: "json", GroupBy: "file", ShowSuppressed: tt.showSuppressed})
+
+			var buf bytes.Buffer
+			if err := formatter.Format(result, &buf); err != nil {
+				t.Fatalf("Format failed: %v", err)
+			}
+
+			var output JSONOutput
+			if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+
+			if len(output.Suppressed) != tt.wantSuppressed {
+				t.Fatalf("got %d suppressed issues, want %d", len(output.Suppressed), tt.wantSuppressed)
+			}
+			if tt.wantSuppressed > 0 {
+				if output.Suppressed[0].Reason != "low confidence" || output.Suppressed[0].Relative != "src/main.go" {
+					t.Errorf("unexpected suppressed issue: %+v", output.Suppressed[0])
+				}
+			}
+
+			// Suppressed issues never count towards the main totals
+			if output.Summary.TotalIssues != 5 {
+				t.Errorf("total issues = %d, want 5", output.Summary.TotalIssues)
+			}
+			if output.Summary.SuppressedCount != 1 {
+				t.Errorf("suppressed count = %d, want 1", output.Summary.SuppressedCount)
+			}
+			for _, fileResult := range output.Results {
+				for _, issue := range fileResult.Issues {
+					if issue.Title == "Possible typo" {
+						t.Error("suppressed issue leaked into results")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestJSONFormatter_GroupByDirectory(t *testing.T) {
+	result := createTestReviewResult()
+	result.FileReviews[1].File.Relative = "lib/utils.py"
+	formatter := NewJSONFormatter(Config{Format: "json", GroupBy: "directory", ShowSuccess: true})
+
+	var buf bytes.Buffer
+	if err := formatter.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var output JSONOutput
+	if err := json.Unmarshal(buf.Bytes(), &output); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(output.Results) != 0 || len(output.Issues) != 0 {
+		t.Error("directory grouping should only fill directories")
+	}
+	if len(output.Directories) != 2 {
+		t.Fatalf("got %d directories, want 2", len(output.Directories))
+	}
+
+	lib, src := output.Directories[0], output.Directories[1]
+	if lib.Directory != "lib" || len(lib.Files) != 1 || lib.TotalIssues != 2 || lib.WarningCount != 1 || lib.InfoCount != 1 {
+		t.Errorf("unexpected lib directory: %+v", lib)
+	}
+	if src.Directory != "src" || len(src.Files) != 2 || src.TotalIssues != 3 || src.CriticalCount != 1 || src.WarningCount != 2 {
+		t.Errorf("unexpected src directory: %+v", src)
+	}
 }
 
 func TestFormatterFactory(t *testing.T) {
@@ -243,6 +352,31 @@ func TestFormatterFactory(t *testing.T) {
 			format: "jsonl",
 			want:   "*output.JSONFormatter",
 		},
+		{
+			name:   "checkstyle formatter",
+			format: "checkstyle",
+			want:   "*output.CheckstyleFormatter",
+		},
+		{
+			name:   "gitlab formatter",
+			format: "gitlab",
+			want:   "*output.GitLabFormatter",
+		},
+		{
+			name:   "html formatter",
+			format: "html",
+			want:   "*output.HTMLFormatter",
+		},
+		{
+			name:   "markdown formatter",
+			format: "markdown",
+			want:   "*output.MarkdownFormatter",
+		},
+		{
+			name:   "csv formatter",
+			format: "csv",
+			want:   "*output.CSVFormatter",
+		},
 		{
 			name:   "invalid formatter",
 			format: "xml",
@@ -276,3 +410,23 @@ func TestFormatterFactory(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveColor(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+
+	if resolveColor(ColorNever) {
+		t.Error("never should disable color")
+	}
+	if !resolveColor(ColorAlways) {
+		t.Error("always should force color even when not a terminal")
+	}
+
+	// Tests do not run on a terminal, and NO_COLOR disables auto regardless
+	if resolveColor(ColorAuto) {
+		t.Error("auto should not color output that is not a terminal")
+	}
+	t.Setenv("NO_COLOR", "1")
+	if resolveColor(ColorAuto) {
+		t.Error("auto should honor NO_COLOR")
+	}
+}
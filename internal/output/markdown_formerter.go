@@ -0,0 +1,195 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"scanr/internal/review"
+)
+
+// collapseSuggestionsAfter is the number of suggestions shown inline before
+// they are folded into a <details> block
+const collapseSuggestionsAfter = 3
+
+// MarkdownFormatter formats review results as Markdown for PR comments
+type MarkdownFormatter struct {
+	config Config
+}
+
+// NewMarkdownFormatter creates a new Markdown formatter
+func NewMarkdownFormatter(config Config) *MarkdownFormatter {
+	return &MarkdownFormatter{config: config}
+}
+
+// Format writes the summary table followed by per-file issue lists
+func (f *MarkdownFormatter) Format(result *review.ReviewResult, w io.Writer) error {
+	fmt.Fprintf(w, "## scanr Code Review\n\n")
+	f.writeSummary(result, w)
+
+	if f.config.SummaryOnly || result.TotalIssues == 0 {
+		return nil
+	}
+
+	issuesWritten := 0
+	for _, fileReview := range result.FileReviews {
+		if f.config.MaxIssues > 0 && issuesWritten >= f.config.MaxIssues {
+			break
+		}
+		issuesWritten += f.writeFile(&fileReview, issuesWritten, w)
+	}
+
+	f.writeTruncated(result.TotalIssues, issuesWritten, w)
+	return nil
+}
+
+// FormatStream writes each file section as it arrives and the summary table last
+func (f *MarkdownFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writer) error {
+	fmt.Fprintf(w, "## scanr Code Review\n\n")
+
+	partial := &review.ReviewResult{}
+	issuesWritten := 0
+	for fileReview := range issues {
+		accumulateFileReview(partial, fileReview)
+
+		if f.config.SummaryOnly {
+			continue
+		}
+		if f.config.MaxIssues > 0 && issuesWritten >= f.config.MaxIssues {
+			continue
+		}
+		issuesWritten += f.writeFile(fileReview, issuesWritten, w)
+	}
+
+	if !f.config.SummaryOnly {
+		f.writeTruncated(partial.TotalIssues, issuesWritten, w)
+	}
+
+	f.writeSummary(partial, w)
+	return nil
+}
+
+// writeSummary writes the files and severity table
+func (f *MarkdownFormatter) writeSummary(result *review.ReviewResult, w io.Writer) {
+	fmt.Fprintf(w, "| Files reviewed | Issues | Critical | Warning | Info |\n")
+	fmt.Fprintf(w, "|---:|---:|---:|---:|---:|\n")
+	fmt.Fprintf(w, "| %d/%d | %d | %d | %d | %d |\n\n",
+		result.ReviewedFiles, result.TotalFiles, result.TotalIssues,
+		result.CriticalCount, result.WarningCount, result.InfoCount)
+
+	if result.TotalIssues == 0 {
+		fmt.Fprintf(w, "No issues found.\n\n")
+	}
+}
+
+// writeFile writes one file section and returns the number of issues written
+func (f *MarkdownFormatter) writeFile(fileReview *review.FileReview, alreadyWritten int, w io.Writer) int {
+	if len(fileReview.Issues) == 0 {
+		return 0
+	}
+
+	path := fileReviewPath(fileReview)
+	fmt.Fprintf(w, "### `%s`\n\n", path)
+
+	written := 0
+	for _, issue := range sortIssuesBySeverity(fileReview.Issues) {
+		if f.config.MaxIssues > 0 && alreadyWritten+written >= f.config.MaxIssues {
+			break
+		}
+		f.writeIssue(issue, w)
+		written++
+	}
+	fmt.Fprintf(w, "\n")
+
+	return written
+}
+
+// writeIssue writes a single issue as a bullet
+func (f *MarkdownFormatter) writeIssue(issue review.Issue, w io.Writer) {
+	fmt.Fprintf(w, "- **%s** %s", markdownSeverity(issue.Severity), escapeMarkdown(issue.Title))
+
+	var details []string
+	if issue.Line > 0 {
+		details = append(details, fmt.Sprintf("line %d", issue.Line))
+	}
+	if issue.Code != "" {
+		details = append(details, fmt.Sprintf("`%s`", issue.Code))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(w, " (%s)", strings.Join(details, ", "))
+	}
+	fmt.Fprintf(w, "\n")
+
+	if issue.Description != "" {
+		fmt.Fprintf(w, "  %s\n", escapeMarkdown(issue.Description))
+	}
+
+	if len(issue.Suggestions) == 0 {
+		return
+	}
+
+	if len(issue.Suggestions) > collapseSuggestionsAfter {
+		fmt.Fprintf(w, "  <details><summary>%d suggestions</summary>\n\n", len(issue.Suggestions))
+		for _, suggestion := range issue.Suggestions {
+			fmt.Fprintf(w, "  - %s\n", escapeMarkdown(suggestion))
+		}
+		fmt.Fprintf(w, "\n  </details>\n")
+		return
+	}
+
+	for _, suggestion := range issue.Suggestions {
+		fmt.Fprintf(w, "  - %s\n", escapeMarkdown(suggestion))
+	}
+}
+
+// writeTruncated notes how many issues MaxIssues left out
+func (f *MarkdownFormatter) writeTruncated(total, written int, w io.Writer) {
+	if f.config.MaxIssues > 0 && total > written {
+		fmt.Fprintf(w, "_... and %d more issues_\n\n", total-written)
+	}
+}
+
+// markdownSeverity returns the label for a severity
+func markdownSeverity(severity review.Severity) string {
+	switch severity {
+	case review.SeverityCritical:
+		return "🔴 CRITICAL"
+	case review.SeverityHigh:
+		return "🟡 WARNING"
+	default:
+		return "🔵 INFO"
+	}
+}
+
+// sortIssuesBySeverity orders issues critical first, then by line
+func sortIssuesBySeverity(issues []review.Issue) []review.Issue {
+	rank := map[review.Severity]int{
+		review.SeverityCritical: 3,
+		review.SeverityHigh:     2,
+		review.SeverityInfo:     1,
+	}
+
+	sorted := make([]review.Issue, len(issues))
+	copy(sorted, issues)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if rank[sorted[i].Severity] == rank[sorted[j].Severity] {
+			return sorted[i].Line < sorted[j].Line
+		}
+		return rank[sorted[i].Severity] > rank[sorted[j].Severity]
+	})
+	return sorted
+}
+
+// markdownEscaper keeps reviewer text from breaking the surrounding markup
+var markdownEscaper = strings.NewReplacer(
+	"<", "&lt;",
+	">", "&gt;",
+	"|", "\\|",
+	"\n", " ",
+)
+
+// escapeMarkdown escapes text for use inside list items
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
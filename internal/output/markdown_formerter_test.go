@@ -0,0 +1,86 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"scanr/internal/review"
+)
+
+func TestMarkdownFormatter_Format(t *testing.T) {
+	result := createTestReviewResult()
+	result.FileReviews[0].Issues[1].Suggestions = []string{"Split it", "Extract helpers", "Use a table", "Add tests"}
+
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter(Config{Format: "markdown"}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	output := buf.String()
+
+	checks := []string{
+		"| 8/10 | 5 | 1 | 3 | 1 |",
+		"### `src/main.go`",
+		"- **🔴 CRITICAL** Hardcoded API key (line 25, `SEC001`)",
+		"  - Use environment variable",
+		"<details><summary>4 suggestions</summary>",
+		"### `src/utils.py`",
+	}
+	for _, check := range checks {
+		if !strings.Contains(output, check) {
+			t.Errorf("output missing %q\n%s", check, output)
+		}
+	}
+
+	// Files without issues get no section
+	if strings.Contains(output, "clean.go") {
+		t.Error("clean file should not have a section")
+	}
+}
+
+func TestMarkdownFormatter_MaxIssuesAndSummaryOnly(t *testing.T) {
+	result := createTestReviewResult()
+
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter(Config{MaxIssues: 2}).Format(result, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(buf.String(), "\n- **"); got != 2 {
+		t.Errorf("wrote %d issues, want 2", got)
+	}
+	if !strings.Contains(buf.String(), "_... and 3 more issues_") {
+		t.Error("missing truncation note")
+	}
+
+	buf.Reset()
+	if err := NewMarkdownFormatter(Config{SummaryOnly: true}).Format(result, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "###") {
+		t.Error("summary-only output should not include file sections")
+	}
+}
+
+func TestMarkdownFormatter_Stream(t *testing.T) {
+	result := createTestReviewResult()
+
+	stream := make(chan *review.FileReview, len(result.FileReviews))
+	for i := range result.FileReviews {
+		stream <- &result.FileReviews[i]
+	}
+	close(stream)
+
+	var buf bytes.Buffer
+	if err := NewMarkdownFormatter(Config{}).FormatStream(stream, &buf); err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "| 3/3 | 5 | 1 | 3 | 1 |") {
+		t.Errorf("missing streamed summary:\n%s", buf.String())
+	}
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	if got := escapeMarkdown("a <b> | c\nd"); got != `a &lt;b&gt; \| c d` {
+		t.Errorf("escapeMarkdown = %q", got)
+	}
+}
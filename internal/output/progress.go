@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"scanr/internal/review"
+)
+
+// ProgressReporter prints review progress while the pipeline runs
+type ProgressReporter struct {
+	w       io.Writer
+	inline  bool
+	mu      sync.Mutex
+	written bool
+}
+
+// NewProgressReporter creates a reporter writing to w. When stdout is a
+// terminal the progress line is updated in place, otherwise one line is
+// printed per update.
+func NewProgressReporter(w io.Writer) *ProgressReporter {
+	return &ProgressReporter{
+		w:      w,
+		inline: isTerminal(),
+	}
+}
+
+// Update prints the current progress; it matches review.ProgressFunc
+func (r *ProgressReporter) Update(reviewed, total int, result review.ReviewResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	line := fmt.Sprintf("reviewed %d/%d (%d critical, %d warning, %d info so far)",
+		reviewed, total, result.CriticalCount, result.WarningCount, result.InfoCount)
+
+	if r.inline {
+		// Clear the rest of the previous line before rewriting it
+		fmt.Fprintf(r.w, "\r%s\033[K", line)
+	} else {
+		fmt.Fprintln(r.w, line)
+	}
+	r.written = true
+}
+
+// Done terminates an in-place progress line so later output starts cleanly
+func (r *ProgressReporter) Done() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inline && r.written {
+		fmt.Fprintln(r.w)
+	}
+	r.written = false
+}
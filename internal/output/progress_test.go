@@ -0,0 +1,43 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"scanr/internal/review"
+)
+
+func TestProgressReporter_PlainLines(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := &ProgressReporter{w: &buf}
+
+	reporter.Update(1, 3, review.ReviewResult{CriticalCount: 1})
+	reporter.Update(2, 3, review.ReviewResult{CriticalCount: 1, WarningCount: 2, InfoCount: 4})
+	reporter.Done()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	if lines[1] != "reviewed 2/3 (1 critical, 2 warning, 4 info so far)" {
+		t.Errorf("unexpected progress line: %q", lines[1])
+	}
+}
+
+func TestProgressReporter_Inline(t *testing.T) {
+	var buf bytes.Buffer
+	reporter := &ProgressReporter{w: &buf, inline: true}
+
+	reporter.Update(1, 2, review.ReviewResult{})
+	reporter.Update(2, 2, review.ReviewResult{})
+	reporter.Done()
+
+	output := buf.String()
+	if strings.Count(output, "\r") != 2 {
+		t.Errorf("expected carriage returns for each update: %q", output)
+	}
+	if !strings.HasSuffix(output, "\n") || strings.Count(output, "\n") != 1 {
+		t.Errorf("expected a single trailing newline: %q", output)
+	}
+}
@@ -3,17 +3,23 @@ package output
 import (
 	"fmt"
 	"io"
+	"path/filepath"
+	"regexp"
 	"scanr/internal/review"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 )
 
 // Formats review results as human-readable text
 type TextFormatter struct {
-	config Config
+	config  Config
+	sources map[string][]string // file contents read for source context
 }
 
 // NewTextFormatter creates a new text formatter
@@ -33,9 +39,95 @@ func (f *TextFormatter) Format(result *review.ReviewResult, w io.Writer) error {
 	return nil
 }
 
-// Formats streaming review results
+// Formats streaming review results, writing each file as it arrives and
+// a summary of everything streamed once the channel is closed
 func (f *TextFormatter) FormatStream(issues <-chan *review.FileReview, w io.Writer) error {
-	return fmt.Errorf("stream formatting not supported for text output")
+	partial := &review.ReviewResult{StartTime: time.Now(), MinConfidence: f.config.MinConfidence}
+	f.writeHeader(partial, w)
+
+	issuesWritten := 0
+	for fileReview := range issues {
+		accumulateFileReview(partial, fileReview)
+
+		if f.config.SummaryOnly {
+			continue
+		}
+		if len(fileReview.Issues) == 0 && !f.config.ShowSuccess {
+			continue
+		}
+		if f.config.MaxIssues > 0 && issuesWritten >= f.config.MaxIssues {
+			continue
+		}
+
+		f.writeFileHeader(fileReview, w)
+		for _, issue := range f.sortIssues(fileReview.Issues) {
+			if f.config.MaxIssues > 0 && issuesWritten >= f.config.MaxIssues {
+				break
+			}
+			f.writeIssue(issue, w)
+			issuesWritten++
+		}
+
+		if len(fileReview.Issues) == 0 {
+			successColor := color.New(color.FgGreen)
+			if f.config.Color {
+				successColor.Fprintf(w, "  ✅ No issues found\n")
+			} else {
+				fmt.Fprintf(w, "  ✅ No issues found\n")
+			}
+		}
+		fmt.Fprintf(w, "\n")
+
+		// Show each file as soon as it is written, not when a buffer fills
+		if err := flushWriter(w); err != nil {
+			return err
+		}
+	}
+
+	partial.EndTime = time.Now()
+	partial.Duration = partial.EndTime.Sub(partial.StartTime)
+
+	if f.config.MaxIssues > 0 && partial.TotalIssues > issuesWritten && !f.config.SummaryOnly {
+		fmt.Fprintf(w, "... and %d more issues\n\n", partial.TotalIssues-issuesWritten)
+	}
+
+	f.writeSummary(partial, w)
+	f.writeFooter(partial, w)
+	return flushWriter(w)
+}
+
+// flushWriter flushes writers that buffer output, such as *bufio.Writer and
+// http.Flusher implementations
+func flushWriter(w io.Writer) error {
+	switch fw := w.(type) {
+	case interface{ Flush() error }:
+		return fw.Flush()
+	case interface{ Flush() }:
+		fw.Flush()
+	}
+	return nil
+}
+
+// accumulateFileReview adds a streamed file review to a running result
+func accumulateFileReview(result *review.ReviewResult, fileReview *review.FileReview) {
+	result.TotalFiles++
+	result.SuppressedCount += len(fileReview.Suppressed)
+	if fileReview.Error != "" {
+		return
+	}
+
+	result.ReviewedFiles++
+	for _, issue := range fileReview.Issues {
+		result.TotalIssues++
+		switch issue.Severity {
+		case review.SeverityCritical:
+			result.CriticalCount++
+		case review.SeverityHigh:
+			result.WarningCount++
+		case review.SeverityInfo:
+			result.InfoCount++
+		}
+	}
 }
 
 // writeHeader writes the report header
@@ -48,7 +140,12 @@ func (f *TextFormatter) writeHeader(result *review.ReviewResult, w io.Writer) {
 	fmt.Fprintf(w, "%s\n", separator)
 
 	fmt.Fprintf(w, "Date:     %s\n", result.StartTime.Format(time.RFC1123))
-	fmt.Fprintf(w, "Duration: %v\n", result.Duration.Round(time.Millisecond))
+	if result.Duration > 0 {
+		fmt.Fprintf(w, "Duration: %v\n", result.Duration.Round(time.Millisecond))
+	}
+	if result.MinConfidence > 0 {
+		fmt.Fprintf(w, "Filter:   confidence >= %.2f\n", result.MinConfidence)
+	}
 	fmt.Fprintf(w, "\n")
 }
 
@@ -91,6 +188,17 @@ func (f *TextFormatter) writeSummary(result *review.ReviewResult, w io.Writer) {
 	}
 
 	fmt.Fprintf(w, "  Total:     %d\n", result.TotalIssues)
+	if result.DuplicatesRemoved > 0 {
+		fmt.Fprintf(w, "  Duplicates removed: %d\n", result.DuplicatesRemoved)
+	}
+
+	if f.config.ShowSuppressed && result.SuppressedCount > 0 {
+		fmt.Fprintf(w, "\n  * %d suppressed issue(s) not counted above", result.SuppressedCount)
+		if reasons := suppressionReasons(result); reasons != "" {
+			fmt.Fprintf(w, " (%s)", reasons)
+		}
+		fmt.Fprintf(w, "\n")
+	}
 
 	// Success message if no issues
 	if result.TotalIssues == 0 {
@@ -106,16 +214,43 @@ func (f *TextFormatter) writeSummary(result *review.ReviewResult, w io.Writer) {
 	fmt.Fprintf(w, "\n")
 }
 
+// suppressionReasons summarises suppressed issues by reason, e.g. "low confidence: 2"
+func suppressionReasons(result *review.ReviewResult) string {
+	counts := make(map[string]int)
+	var reasons []string
+	for _, fileReview := range result.FileReviews {
+		for _, s := range fileReview.Suppressed {
+			if counts[s.Reason] == 0 {
+				reasons = append(reasons, s.Reason)
+			}
+			counts[s.Reason]++
+		}
+	}
+
+	sort.Strings(reasons)
+	parts := make([]string, len(reasons))
+	for i, reason := range reasons {
+		parts[i] = fmt.Sprintf("%s: %d", reason, counts[reason])
+	}
+	return strings.Join(parts, ", ")
+}
+
 // writeIssues writes individual issues
 func (f *TextFormatter) writeIssues(result *review.ReviewResult, w io.Writer) {
 	// Group and sort issues based on config
 	issuesByFile := f.groupIssuesByFile(result)
 	files := f.getSortedFiles(issuesByFile)
 
+	byDirectory := f.config.GroupBy == "directory"
+	if byDirectory {
+		files = groupFilesByDirectory(files)
+	}
+
 	// Apply max issues limit
 	issuesWritten := 0
+	currentDir := ""
 
-	for _, file := range files {
+	for i, file := range files {
 		if f.config.MaxIssues > 0 && issuesWritten >= f.config.MaxIssues {
 			fmt.Fprintf(w, "... and %d more issues\n", result.TotalIssues-issuesWritten)
 			break
@@ -126,6 +261,11 @@ func (f *TextFormatter) writeIssues(result *review.ReviewResult, w io.Writer) {
 			continue
 		}
 
+		if byDirectory && (i == 0 || filepath.Dir(file) != currentDir) {
+			currentDir = filepath.Dir(file)
+			f.writeDirectoryHeader(currentDir, files[i:], issuesByFile, w)
+		}
+
 		f.writeFileHeader(fileReview, w)
 
 		// Sort issues within file
@@ -153,24 +293,87 @@ func (f *TextFormatter) writeIssues(result *review.ReviewResult, w io.Writer) {
 	}
 }
 
+// groupFilesByDirectory orders files by directory, keeping the existing
+// order of files within each directory
+func groupFilesByDirectory(files []string) []string {
+	grouped := slices.Clone(files)
+	slices.SortStableFunc(grouped, func(a, b string) int {
+		return strings.Compare(filepath.Dir(a), filepath.Dir(b))
+	})
+	return grouped
+}
+
+// writeDirectoryHeader writes the header for a directory section with the
+// counts of the files that follow it in that directory
+func (f *TextFormatter) writeDirectoryHeader(dir string, files []string, issuesByFile map[string]*review.FileReview, w io.Writer) {
+	fileCount := 0
+	severityCounts := make(map[review.Severity]int)
+	total := 0
+	for _, file := range files {
+		if filepath.Dir(file) != dir {
+			break
+		}
+		fileCount++
+		for _, issue := range issuesByFile[file].Issues {
+			severityCounts[issue.Severity]++
+			total++
+		}
+	}
+
+	fmt.Fprintf(w, "%s\n", strings.Repeat("=", 60))
+
+	dirColor := color.New(color.FgMagenta, color.Bold)
+	if f.config.Color {
+		dirColor.Fprintf(w, "%s/", dir)
+	} else {
+		fmt.Fprintf(w, "%s/", dir)
+	}
+
+	fileText := "file"
+	if fileCount != 1 {
+		fileText = "files"
+	}
+	issueText := "issue"
+	if total != 1 {
+		issueText = "issues"
+	}
+	fmt.Fprintf(w, " (%d %s, %d %s", fileCount, fileText, total, issueText)
+
+	var counts []string
+	for _, severity := range []review.Severity{review.SeverityCritical, review.SeverityHigh, review.SeverityInfo} {
+		if severityCounts[severity] > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", severityCounts[severity], severity))
+		}
+	}
+	if len(counts) > 0 {
+		fmt.Fprintf(w, ": %s", strings.Join(counts, ", "))
+	}
+	fmt.Fprintf(w, ")\n\n")
+}
+
 // writeFileHeader writes the header for a file section
 func (f *TextFormatter) writeFileHeader(fileReview *review.FileReview, w io.Writer) {
 	fmt.Fprintf(w, "%s\n", strings.Repeat("-", 60))
 
-	// File path
-	pathColor := color.New(color.FgBlue, color.Bold)
-	if f.config.Color {
-		pathColor.Fprintf(w, "%s", fileReview.File.Relative)
+	// A review without file info still gets a header
+	if fileReview.File == nil {
+		fmt.Fprintf(w, "<unknown file>\n")
 	} else {
-		fmt.Fprintf(w, "%s", fileReview.File.Relative)
-	}
+		// File path
+		pathColor := color.New(color.FgBlue, color.Bold)
+		if f.config.Color {
+			pathColor.Fprintf(w, "%s", fileReview.File.Relative)
+		} else {
+			fmt.Fprintf(w, "%s", fileReview.File.Relative)
+		}
 
-	// File info
-	fmt.Fprintf(w, " (%s, %d lines", fileReview.File.Languages, fileReview.File.Lines)
-	if fileReview.Duration > 0 {
-		fmt.Fprintf(w, ", reviewed in %v", fileReview.Duration.Round(time.Millisecond))
+		// File info
+		fmt.Fprintf(w, " (%s, %d lines", fileReview.File.Languages, fileReview.File.Lines)
+		if fileReview.Duration > 0 {
+			fmt.Fprintf(w, ", reviewed in %v", fileReview.Duration.Round(time.Millisecond))
+		}
+		fmt.Fprintf(w, ")\n")
 	}
-	fmt.Fprintf(w, ")\n")
 
 	// Issue count
 	if len(fileReview.Issues) > 0 {
@@ -217,11 +420,16 @@ func (f *TextFormatter) writeIssue(issue review.Issue, w io.Writer) {
 	fmt.Fprintf(w, "  %s %s\n", severityStr, location)
 
 	// Title (indented)
-	fmt.Fprintf(w, "    %s\n", issue.Title)
+	fmt.Fprintf(w, "%s\n", f.wrap(issue.Title, "    ", "    "))
 
 	// Description
 	if issue.Description != "" {
-		fmt.Fprintf(w, "    %s\n", issue.Description)
+		fmt.Fprintf(w, "%s\n", f.wrap(issue.Description, "    ", "    "))
+	}
+
+	// Source context
+	if f.config.ContextLines > 0 {
+		f.writeSourceContext(issue, w)
 	}
 
 	// Code reference
@@ -238,10 +446,16 @@ func (f *TextFormatter) writeIssue(issue review.Issue, w io.Writer) {
 	if len(issue.Suggestions) > 0 {
 		fmt.Fprintf(w, "    Suggestions:\n")
 		for _, suggestion := range issue.Suggestions {
-			fmt.Fprintf(w, "    • %s\n", suggestion)
+			fmt.Fprintf(w, "%s\n", f.wrap(suggestion, "    • ", "      "))
 		}
 	}
 
+	// Suggested fix, left unindented so the diff can be applied as copied
+	if f.config.ShowFixes && issue.FixSuggestion != "" {
+		fmt.Fprintf(w, "    Suggested fix:\n")
+		fmt.Fprintf(w, "```diff\n%s\n```\n", strings.TrimRight(issue.FixSuggestion, "\n"))
+	}
+
 	// Confidence
 	if issue.Confidence > 0 {
 		confidence := fmt.Sprintf("%.0f%%", issue.Confidence*100)
@@ -260,6 +474,94 @@ func (f *TextFormatter) writeIssue(issue review.Issue, w io.Writer) {
 	fmt.Fprintf(w, "\n")
 }
 
+// wrap word-wraps text to the configured width. The first line starts with
+// prefix and continuation lines with indent so they align with the text.
+func (f *TextFormatter) wrap(text, prefix, indent string) string {
+	if f.config.WrapWidth <= 0 {
+		return prefix + text
+	}
+
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return prefix
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	lineLen := visibleLen(prefix)
+	lineStart := true
+
+	for _, word := range words {
+		wordLen := visibleLen(word)
+		if !lineStart && lineLen+1+wordLen > f.config.WrapWidth {
+			b.WriteString("\n")
+			b.WriteString(indent)
+			lineLen = visibleLen(indent)
+			lineStart = true
+		}
+		if !lineStart {
+			b.WriteString(" ")
+			lineLen++
+		}
+		b.WriteString(word)
+		lineLen += wordLen
+		lineStart = false
+	}
+
+	return b.String()
+}
+
+// ansiEscape matches terminal color sequences
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// visibleLen returns the printed width of s, ignoring ANSI color codes
+func visibleLen(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// writeSourceContext prints the lines around an issue with the offending line marked
+func (f *TextFormatter) writeSourceContext(issue review.Issue, w io.Writer) {
+	snippet := buildSnippet(f.sourceLines(issue.FilePath), issue.Line, f.config.ContextLines)
+	if len(snippet) == 0 {
+		return
+	}
+
+	width := len(strconv.Itoa(snippet[len(snippet)-1].Number))
+	highlight := color.New(color.FgRed, color.Bold)
+
+	for _, line := range snippet {
+		marker := " "
+		if line.Highlight {
+			marker = ">"
+		}
+		text := fmt.Sprintf("    %s %*d | %s\n", marker, width, line.Number, line.Text)
+
+		if line.Highlight && f.config.Color {
+			highlight.Fprint(w, text)
+		} else {
+			fmt.Fprint(w, text)
+		}
+	}
+}
+
+// sourceLines returns the lines of a file, reading each file at most once per run
+func (f *TextFormatter) sourceLines(path string) []string {
+	if path == "" {
+		return nil
+	}
+	if f.sources == nil {
+		f.sources = make(map[string][]string)
+	}
+
+	lines, ok := f.sources[path]
+	if !ok {
+		// Unreadable files are cached as empty so they are not retried
+		lines, _ = readSourceLines(path)
+		f.sources[path] = lines
+	}
+	return lines
+}
+
 // writeFooter writes the report footer
 func (f *TextFormatter) writeFooter(result *review.ReviewResult, w io.Writer) {
 	width := 70
@@ -268,7 +570,9 @@ func (f *TextFormatter) writeFooter(result *review.ReviewResult, w io.Writer) {
 	fmt.Fprintf(w, "%s\n", separator)
 
 	// Exit code guidance
-	if result.CriticalCount > 0 {
+	if result.Interrupted {
+		fmt.Fprintf(w, "⏹  Review interrupted; results are partial. Exit code: %d\n", ExitInterrupted)
+	} else if result.CriticalCount > 0 {
 		fmt.Fprintf(w, "❌ Critical issues found. Exit code: 2\n")
 	} else if result.WarningCount > 0 {
 		fmt.Fprintf(w, "⚠️  Warnings found. Exit code: 1\n")
@@ -305,10 +609,21 @@ func (f *TextFormatter) formatSeverity(severity review.Severity, w io.Writer) st
 func (f *TextFormatter) groupIssuesByFile(result *review.ReviewResult) map[string]*review.FileReview {
 	issuesByFile := make(map[string]*review.FileReview)
 
-	for _, fileReview := range result.FileReviews {
-		if len(fileReview.Issues) > 0 || f.config.ShowSuccess {
-			issuesByFile[fileReview.File.Relative] = &fileReview
+	// Index into the slice so each entry points at its own FileReview
+	for i := range result.FileReviews {
+		fileReview := &result.FileReviews[i]
+		if len(fileReview.Issues) == 0 && !f.config.ShowSuccess {
+			continue
+		}
+
+		// Reviews without file info fall back to the path their issues report
+		key := ""
+		if fileReview.File != nil {
+			key = fileReview.File.Relative
+		} else if len(fileReview.Issues) > 0 {
+			key = fileReview.Issues[0].FilePath
 		}
+		issuesByFile[key] = fileReview
 	}
 
 	return issuesByFile
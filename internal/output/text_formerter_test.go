@@ -1,7 +1,11 @@
 package output
 
 import (
+	"bufio"
 	"bytes"
+	"io"
+	"os"
+	"path/filepath"
 	"scanr/internal/fs"
 	"scanr/internal/review"
 	"strings"
@@ -203,6 +207,155 @@ func TestTextFormatter_Format(t *testing.T) {
 	}
 }
 
+func TestTextFormatter_SuppressedFootnote(t *testing.T) {
+	result := createTestReviewResult()
+	result.FileReviews[1].Suppressed = []review.SuppressedIssue{
+		{Issue: review.Issue{Title: "Possible typo", Line: 3}, Reason: "low confidence"},
+		{Issue: review.Issue{Title: "Trailing space", Line: 9}, Reason: "low confidence"},
+	}
+	result.SuppressedCount = 2
+
+	var buf bytes.Buffer
+	if err := NewTextFormatter(Config{Format: "text", ShowSuppressed: true}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "2 suppressed issue(s) not counted above (low confidence: 2)") {
+		t.Errorf("missing suppressed footnote:\n%s", output)
+	}
+	if !strings.Contains(output, "Total:     5") {
+		t.Errorf("suppressed issues should not change the total:\n%s", output)
+	}
+	if strings.Contains(output, "Possible typo") {
+		t.Error("suppressed issue should not be listed with the results")
+	}
+
+	buf.Reset()
+	if err := NewTextFormatter(Config{Format: "text"}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "suppressed") {
+		t.Error("footnote should only appear with ShowSuppressed")
+	}
+}
+
+func TestTextFormatter_Stream(t *testing.T) {
+	result := createTestReviewResult()
+
+	formatter := NewTextFormatter(Config{Format: "text", Color: false, SortBy: "severity"})
+
+	reviews := make(chan *review.FileReview, len(result.FileReviews))
+	for i := range result.FileReviews {
+		reviews <- &result.FileReviews[i]
+	}
+	close(reviews)
+
+	var buf bytes.Buffer
+	if err := formatter.FormatStream(reviews, &buf); err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+
+	output := buf.String()
+
+	// File sections are written before the summary
+	fileIdx := strings.Index(output, "src/main.go")
+	summaryIdx := strings.Index(output, "SUMMARY")
+	if fileIdx < 0 || summaryIdx < 0 || fileIdx > summaryIdx {
+		t.Errorf("expected file sections before summary:\n%s", output)
+	}
+
+	for _, want := range []string{"Total:     3", "Critical:  1", "Warnings:  3", "Info:      1", "Exit code: 2"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("stream output missing %q:\n%s", want, output)
+		}
+	}
+
+	if strings.Contains(output, "src/clean.go") {
+		t.Error("files without issues should be omitted unless ShowSuccess is set")
+	}
+}
+
+func TestTextFormatter_StreamFilterHeader(t *testing.T) {
+	result := createTestReviewResult()
+	result.MinConfidence = 0.7
+
+	config := Config{Format: "text"}
+	WithMinConfidence(0.7)(&config)
+	formatter := NewTextFormatter(config)
+
+	var formatted bytes.Buffer
+	if err := formatter.Format(result, &formatted); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	reviews := make(chan *review.FileReview, len(result.FileReviews))
+	for i := range result.FileReviews {
+		reviews <- &result.FileReviews[i]
+	}
+	close(reviews)
+	var streamed bytes.Buffer
+	if err := formatter.FormatStream(reviews, &streamed); err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+
+	// Both outputs name the filter in the header
+	want := "Filter:   confidence >= 0.70"
+	for name, output := range map[string]string{"format": formatted.String(), "stream": streamed.String()} {
+		if !strings.Contains(output, want) {
+			t.Errorf("%s output missing %q:\n%s", name, want, output)
+		}
+	}
+}
+
+func TestTextFormatter_StreamFlushes(t *testing.T) {
+	result := createTestReviewResult()
+	formatter := NewTextFormatter(Config{Format: "text"})
+
+	// Nothing reaches the pipe unless the buffered writer is flushed
+	pr, pw := io.Pipe()
+	buffered := bufio.NewWriterSize(pw, 64*1024)
+
+	reviews := make(chan *review.FileReview)
+	done := make(chan error, 1)
+	go func() {
+		done <- formatter.FormatStream(reviews, buffered)
+		pw.Close()
+	}()
+
+	reviews <- &result.FileReviews[0]
+
+	// The first file is readable while the stream is still open
+	seen := make(chan string, 1)
+	go func() {
+		var out strings.Builder
+		chunk := make([]byte, 4096)
+		for !strings.Contains(out.String(), "src/main.go") {
+			n, err := pr.Read(chunk)
+			out.Write(chunk[:n])
+			if err != nil {
+				break
+			}
+		}
+		seen <- out.String()
+		io.Copy(io.Discard, pr)
+	}()
+
+	select {
+	case out := <-seen:
+		if !strings.Contains(out, "src/main.go") {
+			t.Errorf("first file not written before the stream closed:\n%s", out)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("first file was not flushed")
+	}
+
+	close(reviews)
+	if err := <-done; err != nil {
+		t.Fatalf("FormatStream failed: %v", err)
+	}
+}
+
 func TestTextFormatter_Sorting(t *testing.T) {
 	result := createTestReviewResult()
 
@@ -330,6 +483,14 @@ func TestDetermineExitCode(t *testing.T) {
 			result:   nil,
 			expected: 2,
 		},
+		{
+			name: "interrupted",
+			result: &review.ReviewResult{
+				CriticalCount: 1,
+				Interrupted:   true,
+			},
+			expected: ExitInterrupted,
+		},
 	}
 
 	for _, tt := range tests {
@@ -341,3 +502,237 @@ func TestDetermineExitCode(t *testing.T) {
 		})
 	}
 }
+
+func TestTextFormatter_SourceContext(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	source := "package main\n\nfunc main() {\n\tpanic(\"boom\")\n}\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := &review.ReviewResult{
+		TotalFiles:    1,
+		ReviewedFiles: 1,
+		TotalIssues:   1,
+		CriticalCount: 1,
+		FileReviews: []review.FileReview{
+			{
+				File: &fs.FileInfo{Path: path, Relative: "main.go"},
+				Issues: []review.Issue{
+					{FilePath: path, Line: 4, Title: "Panic in main", Severity: review.SeverityCritical},
+				},
+			},
+		},
+	}
+
+	formatter := NewTextFormatter(Config{Format: "text", ContextLines: 1})
+
+	var buf bytes.Buffer
+	if err := formatter.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		"      3 | func main() {",
+		"    > 4 | \tpanic(\"boom\")",
+		"      5 | }",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "2 | ") {
+		t.Error("only one line of context expected on each side")
+	}
+
+	// File contents are cached for the rest of the run
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := formatter.Format(result, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "> 4 |") {
+		t.Error("expected cached source to be reused")
+	}
+}
+
+func TestTextFormatter_GroupIssuesByFile(t *testing.T) {
+	result := createTestReviewResult()
+	formatter := NewTextFormatter(Config{Format: "text", ShowSuccess: true})
+
+	grouped := formatter.groupIssuesByFile(result)
+
+	if len(grouped) != 3 {
+		t.Fatalf("expected 3 grouped files, got %d", len(grouped))
+	}
+
+	seen := make(map[*review.FileReview]bool)
+	for relative, fileReview := range grouped {
+		if fileReview.File.Relative != relative {
+			t.Errorf("entry %s points at %s", relative, fileReview.File.Relative)
+		}
+		if seen[fileReview] {
+			t.Errorf("entry %s shares its FileReview with another file", relative)
+		}
+		seen[fileReview] = true
+	}
+
+	// Entries alias the result rather than a copy
+	if grouped["src/main.go"] != &result.FileReviews[0] {
+		t.Error("grouped entry should point into result.FileReviews")
+	}
+}
+
+func TestTextFormatter_GroupByDirectory(t *testing.T) {
+	result := createTestReviewResult()
+	result.FileReviews[1].File.Relative = "lib/utils.py"
+	formatter := NewTextFormatter(Config{Format: "text", GroupBy: "directory"})
+
+	var buf bytes.Buffer
+	if err := formatter.Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	output := buf.String()
+
+	libHeader := "lib/ (1 file, 2 issues: 1 warning, 1 info)"
+	srcHeader := "src/ (1 file, 3 issues: 1 critical, 2 warning)"
+	for _, want := range []string{libHeader, srcHeader, "lib/utils.py", "src/main.go"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+
+	// Directories are listed in path order, each before its files
+	if !(strings.Index(output, libHeader) < strings.Index(output, "lib/utils.py (") &&
+		strings.Index(output, "lib/utils.py (") < strings.Index(output, srcHeader) &&
+		strings.Index(output, srcHeader) < strings.Index(output, "src/main.go (")) {
+		t.Errorf("unexpected section order:\n%s", output)
+	}
+}
+
+func TestTextFormatter_Wrap(t *testing.T) {
+	formatter := NewTextFormatter(Config{WrapWidth: 20})
+
+	got := formatter.wrap("the quick brown fox jumps over the lazy dog", "    ", "    ")
+	want := "    the quick brown\n    fox jumps over\n    the lazy dog"
+	if got != want {
+		t.Errorf("wrap =\n%q\nwant\n%q", got, want)
+	}
+
+	// Continuation lines align with the text after the bullet
+	got = formatter.wrap("use a named constant", "    • ", "      ")
+	want = "    • use a named\n      constant"
+	if got != want {
+		t.Errorf("wrap =\n%q\nwant\n%q", got, want)
+	}
+
+	// Color codes do not count towards the width
+	colored := "\x1b[31mred\x1b[0m"
+	if visibleLen(colored) != 3 {
+		t.Errorf("visibleLen(%q) = %d, want 3", colored, visibleLen(colored))
+	}
+	got = formatter.wrap(colored+" "+colored+" "+colored+" "+colored, "", "")
+	if strings.Contains(got, "\n") {
+		t.Errorf("colored words fit in 20 columns but were wrapped: %q", got)
+	}
+
+	// Zero disables wrapping
+	long := strings.Repeat("word ", 30)
+	if got := NewTextFormatter(Config{}).wrap(long, "    ", "    "); got != "    "+long {
+		t.Error("wrapping should be disabled by default")
+	}
+}
+
+func TestFormatters_NilFile(t *testing.T) {
+	result := &review.ReviewResult{
+		TotalFiles:    1,
+		ReviewedFiles: 1,
+		TotalIssues:   1,
+		WarningCount:  1,
+		FileReviews: []review.FileReview{
+			{
+				Issues: []review.Issue{
+					{FilePath: "/project/lost.go", Line: 4, Title: "Unused variable", Severity: review.SeverityHigh},
+				},
+			},
+		},
+	}
+
+	formatters := map[string]Formatter{
+		"text":       NewTextFormatter(Config{Format: "text", ContextLines: 2}),
+		"json":       NewJSONFormatter(Config{Format: "json", GroupBy: "file"}),
+		"flat":       NewJSONFormatter(Config{Format: "json"}),
+		"directory":  NewJSONFormatter(Config{Format: "json", GroupBy: "directory"}),
+		"csv":        NewCSVFormatter(Config{Format: "csv"}),
+		"checkstyle": NewCheckstyleFormatter(Config{Format: "checkstyle"}),
+		"gitlab":     NewGitLabFormatter(Config{Format: "gitlab"}),
+		"html":       NewHTMLFormatter(Config{Format: "html"}),
+		"markdown":   NewMarkdownFormatter(Config{Format: "markdown"}),
+	}
+
+	for name, formatter := range formatters {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := formatter.Format(result, &buf); err != nil {
+				t.Fatalf("Format failed: %v", err)
+			}
+			if !strings.Contains(buf.String(), "Unused variable") {
+				t.Errorf("issue missing from output:\n%s", buf.String())
+			}
+
+			stream := make(chan *review.FileReview, 1)
+			stream <- &result.FileReviews[0]
+			close(stream)
+			if err := formatter.FormatStream(stream, &buf); err != nil {
+				t.Fatalf("FormatStream failed: %v", err)
+			}
+		})
+	}
+}
+
+func TestFormatters_FixSuggestion(t *testing.T) {
+	fix := "--- a/main.go\n+++ b/main.go\n@@ -1,1 +1,2 @@\n+// TODO: handle error\n f()\n"
+	result := &review.ReviewResult{
+		TotalFiles:    1,
+		ReviewedFiles: 1,
+		TotalIssues:   1,
+		WarningCount:  1,
+		FileReviews: []review.FileReview{
+			{
+				File: &fs.FileInfo{Path: "/project/main.go", Relative: "main.go"},
+				Issues: []review.Issue{
+					{FilePath: "/project/main.go", Line: 1, Title: "Unchecked error", Severity: review.SeverityHigh, FixSuggestion: fix},
+				},
+			},
+		},
+	}
+
+	// The text output only includes the diff when asked
+	var buf bytes.Buffer
+	if err := NewTextFormatter(Config{Format: "text"}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if strings.Contains(buf.String(), "Suggested fix") {
+		t.Errorf("fix shown without ShowFixes:\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := NewTextFormatter(Config{Format: "text", ShowFixes: true}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "```diff\n"+fix+"```\n") {
+		t.Errorf("fix missing from output:\n%s", buf.String())
+	}
+
+	// JSON always carries it
+	buf.Reset()
+	if err := NewJSONFormatter(Config{Format: "json"}).Format(result, &buf); err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"fix_suggestion"`) {
+		t.Errorf("fix_suggestion missing from JSON:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,36 @@
+package review
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// IssueFingerprint returns a stable identifier for an issue from its path,
+// title and line. Callers comparing runs across machines should pass a
+// repository-relative path.
+func IssueFingerprint(path, title string, line int) string {
+	sum := sha256.Sum256([]byte(path + title + strconv.Itoa(line)))
+	return hex.EncodeToString(sum[:])
+}
+
+// DeduplicateIssues removes issues with the same file, title and line,
+// keeping the first occurrence
+func DeduplicateIssues(issues []Issue) []Issue {
+	if len(issues) < 2 {
+		return issues
+	}
+
+	seen := make(map[string]bool, len(issues))
+	unique := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		fp := IssueFingerprint(issue.FilePath, issue.Title, issue.Line)
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		unique = append(unique, issue)
+	}
+
+	return unique
+}
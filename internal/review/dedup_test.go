@@ -0,0 +1,35 @@
+package review
+
+import "testing"
+
+func TestDeduplicateIssues(t *testing.T) {
+	issues := []Issue{
+		{FilePath: "/p/main.go", Title: "Unhandled error", Line: 10, Description: "first"},
+		{FilePath: "/p/main.go", Title: "Unhandled error", Line: 10, Description: "second"},
+		{FilePath: "/p/main.go", Title: "Unhandled error", Line: 11},
+		{FilePath: "/p/main.go", Title: "Magic number", Line: 10},
+		{FilePath: "/p/util.go", Title: "Unhandled error", Line: 10},
+	}
+
+	got := DeduplicateIssues(issues)
+
+	if len(got) != 4 {
+		t.Fatalf("expected 4 unique issues, got %d", len(got))
+	}
+	if got[0].Description != "first" {
+		t.Errorf("first occurrence should be kept, got %q", got[0].Description)
+	}
+}
+
+func TestIssueFingerprint(t *testing.T) {
+	a := IssueFingerprint("main.go", "Unhandled error", 10)
+	if a != IssueFingerprint("main.go", "Unhandled error", 10) {
+		t.Error("fingerprint should be stable")
+	}
+	if a == IssueFingerprint("main.go", "Unhandled error", 11) {
+		t.Error("different lines should have different fingerprints")
+	}
+	if len(a) != 64 {
+		t.Errorf("expected hex sha256, got %q", a)
+	}
+}
@@ -4,9 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"scanr/internal/fs"
 	"scanr/internal/worker"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -14,23 +15,56 @@ import (
 
 // Config holds pipeline configuration
 type Config struct {
-	MaxWorkers     int
-	MaxQueueSize   int
-	MaxRetries     int
+	MaxWorkers   int
+	MaxQueueSize int
+	MaxRetries   int
+	// TimeoutPerFile bounds each file's review when TimeoutPerLine is zero
 	TimeoutPerFile time.Duration
+	// TimeoutPerLine scales each file's timeout with its length, never
+	// below MinTimeout
+	TimeoutPerLine time.Duration
 	DeadLetterSize int
 	EnableMetrics  bool
+	// DefaultConfidence is assigned to issues reported without a confidence
+	// score. Zero leaves such issues untouched.
+	DefaultConfidence float64
+	// MinConfidence drops issues scored below it. Zero keeps every issue.
+	MinConfidence float64
+	// FocusAreas keeps only issues in these categories. Empty keeps every issue.
+	FocusAreas []string
+	// DeadLetterFile, when set, receives a JSON line for every failed file
+	// as it fails, so the failures can be inspected after a crash
+	DeadLetterFile string
+	// OnProgress is called after each file result is collected
+	OnProgress ProgressFunc
 }
 
+// MinTimeout is the shortest timeout a file gets when timeouts scale with length
+const MinTimeout = 10 * time.Second
+
+// fileTimeout is how long a single review of file may run
+func (c Config) fileTimeout(file *fs.FileInfo) time.Duration {
+	if c.TimeoutPerLine <= 0 || file == nil {
+		return c.TimeoutPerFile
+	}
+	return max(MinTimeout, time.Duration(file.Lines)*c.TimeoutPerLine)
+}
+
+// ProgressFunc receives the number of files reviewed so far, the total number
+// of files and a snapshot of the running issue counts
+type ProgressFunc func(reviewed, total int, result ReviewResult)
+
 // DefaultConfig returns the default pipeline configuration
 func DefaultConfig() Config {
 	return Config{
-		MaxWorkers:     4,
-		MaxQueueSize:   100,
-		MaxRetries:     2,
-		TimeoutPerFile: 30 * time.Second,
-		DeadLetterSize: 1000,
-		EnableMetrics:  true,
+		MaxWorkers:        4,
+		MaxQueueSize:      100,
+		MaxRetries:        2,
+		TimeoutPerFile:    30 * time.Second,
+		TimeoutPerLine:    30 * time.Millisecond,
+		DeadLetterSize:    1000,
+		EnableMetrics:     true,
+		DefaultConfidence: 0.5,
 	}
 }
 
@@ -48,6 +82,7 @@ type pipeline struct {
 // metrics tracks pipeline performance metrics
 type metrics struct {
 	filesProcessed atomic.Int64
+	filesSkipped   atomic.Int64
 	filesFailed    atomic.Int64
 	filesRetried   atomic.Int64
 	totalIssues    atomic.Int64
@@ -69,12 +104,14 @@ func NewPipeline(config Config, reviewer Reviewer) (Pipeline, error) {
 		config.TimeoutPerFile = 30 * time.Second
 	}
 
-	wp, err := worker.NewWorkerPool(config.MaxWorkers, config.MaxQueueSize)
+	wp, err := worker.NewWorkerPool(config.MaxWorkers, config.MaxQueueSize,
+		worker.WithTaskTimeout(config.fileTimeout))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create worker pool: %w", err)
 	}
 
 	dlq := worker.NewDeadLetterQueue(config.DeadLetterSize)
+	dlq.SetPersistPath(config.DeadLetterFile)
 
 	return &pipeline{
 		config:     config,
@@ -87,6 +124,42 @@ func NewPipeline(config Config, reviewer Reviewer) (Pipeline, error) {
 
 // Run executes the review pipeline on the given files
 func (p *pipeline) Run(ctx context.Context, files []*fs.FileInfo) (*ReviewResult, error) {
+	return p.run(ctx, sliceToChannel(files), len(files), nil)
+}
+
+// RunStream executes the review pipeline and streams each file review as it completes
+func (p *pipeline) RunStream(ctx context.Context, files []*fs.FileInfo, results chan<- *FileReview) (*ReviewResult, error) {
+	defer close(results)
+	return p.run(ctx, sliceToChannel(files), len(files), results)
+}
+
+// RunChannel executes the review pipeline on files as they arrive on the
+// channel, until it is closed. results may be nil; otherwise each file review
+// is sent on it as it completes and it is closed when the run finishes.
+func (p *pipeline) RunChannel(ctx context.Context, files <-chan *fs.FileInfo, results chan<- *FileReview) (*ReviewResult, error) {
+	if results != nil {
+		defer close(results)
+	}
+	return p.run(ctx, files, unknownTotal, results)
+}
+
+// unknownTotal is the file count of runs fed from a channel
+const unknownTotal = -1
+
+// sliceToChannel returns a closed channel holding the given files
+func sliceToChannel(files []*fs.FileInfo) <-chan *fs.FileInfo {
+	ch := make(chan *fs.FileInfo, len(files))
+	for _, file := range files {
+		ch <- file
+	}
+	close(ch)
+	return ch
+}
+
+// run executes the pipeline on the files received from files, optionally
+// streaming file reviews to stream. total is the number of files when known
+// in advance, or unknownTotal.
+func (p *pipeline) run(ctx context.Context, files <-chan *fs.FileInfo, total int, stream chan<- *FileReview) (*ReviewResult, error) {
 	if !p.isRunning.CompareAndSwap(false, true) {
 		return nil, errors.New("pipeline is already running")
 	}
@@ -95,7 +168,7 @@ func (p *pipeline) Run(ctx context.Context, files []*fs.FileInfo) (*ReviewResult
 	startTime := time.Now()
 
 	// Create context with timeout for entire pipeline
-	pipelineCtx, cancel := context.WithTimeout(ctx, p.calculateTimeout(len(files)))
+	pipelineCtx, cancel := context.WithTimeout(ctx, p.calculateTimeout(total))
 	defer cancel()
 
 	// Start worker pool with wrapper to match WorkerFunc signature
@@ -107,45 +180,84 @@ func (p *pipeline) Run(ctx context.Context, files []*fs.FileInfo) (*ReviewResult
 	}
 
 	// Setup result collection
-	resultChan := make(chan worker.TaskResult, len(files))
-	done := make(chan struct{})
+	bufferSize := total
+	if bufferSize < 0 {
+		bufferSize = p.config.MaxQueueSize
+	}
+	resultChan := make(chan worker.TaskResult, bufferSize)
 
-	var result ReviewResult
+	result := ReviewResult{MinConfidence: p.config.MinConfidence, StartTime: startTime}
 	var wg sync.WaitGroup
 
-	// Start result collector
+	// Until a channel run ends, progress reports the files submitted so far
+	var submitted atomic.Int64
+	progressTotal := func() int {
+		if total >= 0 {
+			return total
+		}
+		return int(submitted.Load())
+	}
+
+	// Start result collector. Files queued for retry are held back from the
+	// stream until their retries are done.
+	var held []*fs.FileInfo
 	wg.Add(1)
-	go p.collectResults(pipelineCtx, &result, resultChan, &wg, done)
+	go p.collectResults(pipelineCtx, &result, progressTotal, resultChan, stream, &held, &wg)
 
-	// Submit tasks
-	if err := p.submitTasks(pipelineCtx, files, resultChan); err != nil {
+	// Submit tasks. On failure cancel the queued work, but still shut down
+	// below so no worker or the collector is left blocked on a channel.
+	submitErr := p.submitTasks(pipelineCtx, files, &submitted, resultChan)
+	if submitErr != nil {
 		cancel()
-		return nil, fmt.Errorf("failed to submit tasks: %w", err)
 	}
 
-	// Wait for all tasks to complete before collecting results
-	p.workerPool.Stop()
+	// Wait for all tasks to complete before collecting results. Stop gives up
+	// after a timeout, so also wait for every worker to exit: closing the
+	// channel while a worker is still sending would lose its result.
+	if err := p.workerPool.Stop(); err != nil {
+		slog.Warn("worker pool slow to stop", slog.Any("error", err))
+	}
+	p.workerPool.Wait()
 
-	// Close the result channel so collectResults can finish reading
+	// Close the result channel so collectResults can finish reading. Only the
+	// collector goroutine writes to result, so it is safe to read after wg.Wait.
 	close(resultChan)
 	wg.Wait()
 
-	// Process dead letters (retry logic)
-	// Note: processDeadLetters cannot send on resultChan after it's closed,
-	// so we collect dead letter results separately
-	p.processDeadLetters(pipelineCtx)
+	// An interrupted run still returns the reviews finished before it
+	// stopped, along with the reason, so the caller can report them
+	if err := ctx.Err(); err != nil {
+		result.Interrupted = true
+		p.finalizeResult(&result, startTime, int(submitted.Load()))
+		return &result, err
+	}
 
-	// Finalize result
-	result.EndTime = time.Now()
-	result.Duration = result.EndTime.Sub(startTime)
-	result.TotalFiles = len(files)
+	if submitErr != nil {
+		return nil, fmt.Errorf("failed to submit tasks: %w", submitErr)
+	}
 
-	// Log summary
-	p.logSummary(&result)
+	// Retry failed files. resultChan is closed by now, so retried reviews
+	// are written to result directly and streamed from here.
+	p.processDeadLetters(pipelineCtx, &result, stream)
+	for _, file := range held {
+		if i := failedReview(&result, file); i >= 0 {
+			sendReview(pipelineCtx, stream, result.FileReviews[i])
+		}
+	}
 
+	p.finalizeResult(&result, startTime, int(submitted.Load()))
 	return &result, nil
 }
 
+// finalizeResult records the run's timing and file count and logs a summary
+func (p *pipeline) finalizeResult(result *ReviewResult, startTime time.Time, totalFiles int) {
+	result.EndTime = time.Now()
+	result.Duration = result.EndTime.Sub(startTime)
+	result.TotalFiles = totalFiles
+
+	p.logSummary(result)
+}
+
 // Stop stops the pipeline gracefully
 func (p *pipeline) Stop() error {
 	var err error
@@ -157,64 +269,86 @@ func (p *pipeline) Stop() error {
 	return err
 }
 
-// submitTasks submits all files for review
-func (p *pipeline) submitTasks(ctx context.Context, files []*fs.FileInfo, resultChan chan<- worker.TaskResult) error {
-	batchSize := p.config.MaxWorkers * 2
-	for i := 0; i < len(files); i += batchSize {
-		end := i + batchSize
-		if end > len(files) {
-			end = len(files)
-		}
-
-		batch := files[i:end]
-		if err := p.workerPool.SubmitBatch(ctx, batch, resultChan); err != nil {
-			return fmt.Errorf("failed to submit batch %d-%d: %w", i, end, err)
-		}
-
-		// Check for cancellation
+// submitTasks submits files for review until the channel is closed, waiting
+// for queue space when the workers fall behind. submitted counts the files
+// handed to the worker pool.
+func (p *pipeline) submitTasks(ctx context.Context, files <-chan *fs.FileInfo, submitted *atomic.Int64, resultChan chan<- worker.TaskResult) error {
+	for i := 0; ; i++ {
 		select {
+		case file, ok := <-files:
+			if !ok {
+				return nil
+			}
+			// Count the file first so progress never reports more reviewed than submitted
+			submitted.Add(1)
+			if err := p.workerPool.SubmitWithBackpressure(ctx, i, file, resultChan); err != nil {
+				return fmt.Errorf("failed to submit task %d: %w", i, err)
+			}
 		case <-ctx.Done():
 			return ctx.Err()
-		default:
 		}
 	}
-	return nil
 }
 
-// collectResults collects results from the worker pool
-func (p *pipeline) collectResults(ctx context.Context, result *ReviewResult,
-	resultChan <-chan worker.TaskResult, wg *sync.WaitGroup, done chan<- struct{}) {
+// collectResults collects results from the worker pool. It is the only
+// goroutine that writes to result and held until it returns. Once ctx is done
+// it keeps draining resultChan without processing, so workers never block on
+// a send.
+func (p *pipeline) collectResults(ctx context.Context, result *ReviewResult, total func() int,
+	resultChan <-chan worker.TaskResult, stream chan<- *FileReview, held *[]*fs.FileInfo, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	fileReviews := make([]FileReview, 0)
-	var mu sync.Mutex
 
 	for taskResult := range resultChan {
-		select {
-		case <-ctx.Done():
-			return
-		default:
-			p.processTaskResult(ctx, taskResult, &mu, &fileReviews, result)
+		// Reviews cut short by cancellation are not failures of the file
+		if ctx.Err() != nil || errors.Is(taskResult.Error, context.Canceled) {
+			continue
+		}
+		retrying := p.processTaskResult(ctx, taskResult, &fileReviews, result)
+
+		if p.config.OnProgress != nil {
+			p.config.OnProgress(len(fileReviews), total(), *result)
+		}
+
+		// Hand a copy of the finished review to the stream consumer. A file
+		// queued for retry is streamed once its retries are done.
+		if retrying {
+			*held = append(*held, taskResult.File)
+		} else {
+			sendReview(ctx, stream, fileReviews[len(fileReviews)-1])
 		}
 	}
 
 	// Store final results
 	result.FileReviews = fileReviews
-	close(done)
 }
 
-// processTaskResult processes a single task result
-func (p *pipeline) processTaskResult(ctx context.Context, taskResult worker.TaskResult,
-	mu *sync.Mutex, fileReviews *[]FileReview, result *ReviewResult) {
-
-	mu.Lock()
-	defer mu.Unlock()
+// sendReview sends a copy of fileReview on stream, if there is one
+func sendReview(ctx context.Context, stream chan<- *FileReview, fileReview FileReview) {
+	if stream == nil {
+		return
+	}
+	select {
+	case stream <- &fileReview:
+	case <-ctx.Done():
+	}
+}
 
+// processTaskResult processes a single task result. It reports whether the
+// file was queued for a retry by processDeadLetters.
+func (p *pipeline) processTaskResult(ctx context.Context, taskResult worker.TaskResult,
+	fileReviews *[]FileReview, result *ReviewResult) bool {
 	fileReview := FileReview{
 		File: taskResult.File,
 	}
+	p.metrics.filesProcessed.Add(1)
+	retrying := false
 
-	if taskResult.Error != nil {
+	if errors.Is(taskResult.Error, ErrSkipped) {
+		// A skipped file is neither a failure nor worth retrying
+		p.metrics.filesSkipped.Add(1)
+	} else if taskResult.Error != nil {
 		fileReview.Error = taskResult.Error.Error()
 		p.metrics.filesFailed.Add(1)
 
@@ -227,63 +361,213 @@ func (p *pipeline) processTaskResult(ctx context.Context, taskResult worker.Task
 				Ctx:    ctx,
 			}, taskResult.Error, 1)
 			p.metrics.filesRetried.Add(1)
+			retrying = p.config.MaxRetries > 0
 		}
 	} else {
-		issues := taskResult.Issues.([]Issue)
-		fileReview.Issues = issues
-		fileReview.Duration = 0 // Will be populated by reviewer if available
-		result.ReviewedFiles++
+		p.applyIssues(&fileReview, taskResult.Issues.([]Issue), result)
+	}
+
+	*fileReviews = append(*fileReviews, fileReview)
+	return retrying
+}
 
-		// Count issues by severity
+// applyIssues filters a successful review's issues into fileReview and adds
+// them to the result counts
+func (p *pipeline) applyIssues(fileReview *FileReview, issues []Issue, result *ReviewResult) {
+	applyDefaultConfidence(issues, p.config.DefaultConfidence)
+
+	// Reviewers sometimes report the same finding more than once
+	deduplicated := DeduplicateIssues(issues)
+	result.DuplicatesRemoved += len(issues) - len(deduplicated)
+	issues = deduplicated
+
+	// Honour scanr:ignore comments in the reviewed file
+	if len(issues) > 0 && fileReview.File != nil {
+		var silenced []Issue
+		issues, silenced = filterInlineSuppressed(issues, readSuppressions(fileReview.File.Path))
+		for _, issue := range silenced {
+			fileReview.Suppressed = append(fileReview.Suppressed, SuppressedIssue{
+				Issue:  issue,
+				Reason: "inline comment",
+			})
+		}
+		result.SuppressedCount += len(silenced)
+	}
+
+	// Keep filtered issues around so they can be reported separately
+	kept := filterIssuesByConfidence(issues, p.config.MinConfidence)
+	if len(kept) < len(issues) {
 		for _, issue := range issues {
-			result.TotalIssues++
-			p.metrics.totalIssues.Add(1)
-
-			switch issue.Severity {
-			case SeverityCritical:
-				result.CriticalCount++
-			case SeverityHigh:
-				result.WarningCount++
-			case SeverityInfo:
-				result.InfoCount++
+			if issue.Confidence < p.config.MinConfidence {
+				fileReview.Suppressed = append(fileReview.Suppressed, SuppressedIssue{
+					Issue:  issue,
+					Reason: "low confidence",
+				})
 			}
 		}
+		result.SuppressedCount += len(issues) - len(kept)
+	}
+	issues = kept
+
+	// Drop issues outside the requested focus areas
+	if len(p.config.FocusAreas) > 0 {
+		var outside []Issue
+		issues, outside = filterIssuesByFocus(issues, p.config.FocusAreas)
+		for _, issue := range outside {
+			fileReview.Suppressed = append(fileReview.Suppressed, SuppressedIssue{
+				Issue:  issue,
+				Reason: "outside focus",
+			})
+		}
+		result.SuppressedCount += len(outside)
 	}
 
-	*fileReviews = append(*fileReviews, fileReview)
+	fileReview.Issues = issues
+	fileReview.Duration = 0 // Will be populated by reviewer if available
+	result.ReviewedFiles++
+
+	// Count issues by severity
+	for _, issue := range issues {
+		result.TotalIssues++
+		p.metrics.totalIssues.Add(1)
+
+		switch issue.Severity {
+		case SeverityCritical:
+			result.CriticalCount++
+		case SeverityHigh:
+			result.WarningCount++
+		case SeverityInfo:
+			result.InfoCount++
+		}
+	}
+}
+
+// applyDefaultConfidence fills in the confidence of issues the reviewer left unscored
+func applyDefaultConfidence(issues []Issue, defaultConfidence float64) {
+	if defaultConfidence <= 0 {
+		return
+	}
+	for i := range issues {
+		if issues[i].Confidence <= 0 {
+			issues[i].Confidence = defaultConfidence
+		}
+	}
+}
+
+// filterIssuesByConfidence returns the issues scored at or above min
+func filterIssuesByConfidence(issues []Issue, min float64) []Issue {
+	if min <= 0 {
+		return issues
+	}
+
+	filtered := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Confidence >= min {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// filterIssuesByFocus splits issues into those whose category is one of the
+// focus areas and those outside them
+func filterIssuesByFocus(issues []Issue, focusAreas []string) (kept, outside []Issue) {
+	kept = make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if inFocus(issue.Category, focusAreas) {
+			kept = append(kept, issue)
+		} else {
+			outside = append(outside, issue)
+		}
+	}
+	return kept, outside
+}
+
+// inFocus reports whether category matches one of the focus areas
+func inFocus(category string, focusAreas []string) bool {
+	for _, area := range focusAreas {
+		if strings.EqualFold(category, area) {
+			return true
+		}
+	}
+	return false
 }
 
-// processDeadLetters processes tasks in the dead letter queue
-func (p *pipeline) processDeadLetters(ctx context.Context) {
+// processDeadLetters retries each failed file up to MaxRetries times. A file
+// that succeeds replaces its failed review in result; files that still fail
+// go back to the dead letter queue. Successful retries are sent on stream.
+func (p *pipeline) processDeadLetters(ctx context.Context, result *ReviewResult, stream chan<- *FileReview) {
 	if p.config.MaxRetries <= 0 {
 		return
 	}
 
-	for i := 0; i < p.config.MaxRetries; i++ {
+	var stillFailing []worker.DeadLetter
+	for {
 		dl, ok := p.deadLetter.Pop()
 		if !ok {
 			break
 		}
 
-		// Retry the task
-		retryCtx, cancel := context.WithTimeout(ctx, p.config.TimeoutPerFile)
+		var issues []Issue
+		for dl.Attempts <= p.config.MaxRetries && ctx.Err() == nil {
+			retryCtx, cancel := context.WithTimeout(ctx, p.config.fileTimeout(dl.Task.File))
+			issues, dl.Error = p.reviewer.ReviewFile(retryCtx, dl.Task.File)
+			cancel()
 
-		issues, err := p.reviewer.ReviewFile(retryCtx, dl.Task.File)
-		cancel()
+			dl.Attempts++
+			if dl.Error == nil {
+				break
+			}
+		}
 
-		// Process result directly without sending on closed channel
-		if err == nil && issues != nil {
-			// Successfully retried - update metrics
-			p.metrics.filesRetried.Add(-1) // Remove from retry count
-		} else if err != nil {
-			// Still failing, keep in dead letter for next retry cycle
-			p.deadLetter.Push(dl.Task, err, dl.Attempts+1)
+		if dl.Error != nil || ctx.Err() != nil {
+			stillFailing = append(stillFailing, dl)
+			continue
 		}
+
+		// Successfully retried - update metrics
+		p.metrics.filesRetried.Add(-1) // Remove from retry count
+		sendReview(ctx, stream, p.recordRetry(dl.Task.File, issues, result))
+	}
+
+	for _, dl := range stillFailing {
+		p.deadLetter.Push(dl.Task, dl.Error, dl.Attempts)
 	}
 }
 
-// calculateTimeout calculates the total timeout based on number of files
+// recordRetry replaces the failed review of file with the issues of a
+// successful retry and returns the new review
+func (p *pipeline) recordRetry(file *fs.FileInfo, issues []Issue, result *ReviewResult) FileReview {
+	fileReview := FileReview{File: file}
+	p.applyIssues(&fileReview, issues, result)
+
+	if i := failedReview(result, file); i >= 0 {
+		result.FileReviews[i] = fileReview
+	} else {
+		result.FileReviews = append(result.FileReviews, fileReview)
+	}
+	return fileReview
+}
+
+// failedReview returns the index of the failed review of file, or -1
+func failedReview(result *ReviewResult, file *fs.FileInfo) int {
+	for i := range result.FileReviews {
+		if result.FileReviews[i].File == file && result.FileReviews[i].Error != "" {
+			return i
+		}
+	}
+	return -1
+}
+
+// calculateTimeout calculates the total timeout based on number of files.
+// Runs of unknown size get the maximum timeout.
 func (p *pipeline) calculateTimeout(numFiles int) time.Duration {
+	// Cap at 10 minutes
+	maxTimeout := 10 * time.Minute
+	if numFiles == unknownTotal {
+		return maxTimeout
+	}
+
 	baseTimeout := p.config.TimeoutPerFile * time.Duration(numFiles)
 
 	// Add buffer for pipeline overhead
@@ -292,8 +576,6 @@ func (p *pipeline) calculateTimeout(numFiles int) time.Duration {
 		return 30*time.Second + pipelineOverhead
 	}
 
-	// Cap at 10 minutes
-	maxTimeout := 10 * time.Minute
 	if baseTimeout > maxTimeout {
 		return maxTimeout
 	}
@@ -303,36 +585,49 @@ func (p *pipeline) calculateTimeout(numFiles int) time.Duration {
 
 // logSummary logs a summary of the review
 func (p *pipeline) logSummary(result *ReviewResult) {
-	log.Printf("Review completed:")
-	log.Printf("  Total files: %d", result.TotalFiles)
-	log.Printf("  Reviewed files: %d", result.ReviewedFiles)
-	log.Printf("  Total issues: %d", result.TotalIssues)
-	log.Printf("    Critical: %d", result.CriticalCount)
-	log.Printf("    Warnings: %d", result.WarningCount)
-	log.Printf("    Info: %d", result.InfoCount)
-	log.Printf("  Duration: %v", result.Duration)
+	slog.Info("review completed",
+		slog.Int("total_files", result.TotalFiles),
+		slog.Int("reviewed_files", result.ReviewedFiles),
+		slog.Int("total_issues", result.TotalIssues),
+		slog.Int("critical", result.CriticalCount),
+		slog.Int("warnings", result.WarningCount),
+		slog.Int("info", result.InfoCount),
+		slog.Duration("elapsed", result.Duration),
+	)
 
 	if p.config.EnableMetrics {
 		stats := p.workerPool.Stats()
-		log.Printf("  Worker pool stats:")
-		log.Printf("    Active workers: %d", stats["active"])
-		log.Printf("    Queue size: %d", stats["queue_size"])
-		log.Printf("    Total tasks: %d", stats["total_tasks"])
-		log.Printf("    Failed tasks: %d", stats["failed_tasks"])
-		log.Printf("    Retried tasks: %d", stats["retried_tasks"])
+		slog.Debug("worker pool stats",
+			slog.Int64("active_workers", stats["active"]),
+			slog.Int64("queue_size", stats["queue_size"]),
+			slog.Int64("total_tasks", stats["total_tasks"]),
+			slog.Int64("failed_tasks", stats["failed_tasks"]),
+			slog.Int64("retried_tasks", stats["retried_tasks"]),
+		)
 	}
 
 	if deadLetterCount := p.deadLetter.Size(); deadLetterCount > 0 {
-		log.Printf("  Dead letters: %d", deadLetterCount)
+		slog.Warn("files left in dead letter queue", slog.Int("dead_letters", deadLetterCount))
 	}
 }
 
+// Stats returns the worker pool statistics
+func (p *pipeline) Stats() map[string]int64 {
+	return p.workerPool.Stats()
+}
+
+// DumpDeadLetters writes the files still failing after retries to path
+func (p *pipeline) DumpDeadLetters(path string) error {
+	return p.deadLetter.Dump(path)
+}
+
 // GetMetrics returns pipeline metrics
 func (p *pipeline) GetMetrics() map[string]int64 {
 	return map[string]int64{
 		"files_processed": p.metrics.filesProcessed.Load(),
 		"files_failed":    p.metrics.filesFailed.Load(),
 		"files_retried":   p.metrics.filesRetried.Load(),
+		"files_skipped":   p.metrics.filesSkipped.Load(),
 		"total_issues":    p.metrics.totalIssues.Load(),
 		"total_duration":  p.metrics.totalDuration.Load(),
 	}
@@ -0,0 +1,756 @@
+package review_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"scanr/internal/fs"
+	"scanr/internal/output"
+	"scanr/internal/review"
+	"scanr/internal/worker"
+	"scanr/pkg/reviewer"
+)
+
+// stubReviewer returns a fixed set of issues for every file
+type stubReviewer struct {
+	issues []review.Issue
+}
+
+func (s *stubReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
+	issues := make([]review.Issue, len(s.issues))
+	copy(issues, s.issues)
+	for i := range issues {
+		issues[i].FilePath = file.Path
+	}
+	return issues, nil
+}
+
+func (s *stubReviewer) Name() string {
+	return "stub"
+}
+
+// createTestFiles returns n in-memory file descriptors for the pipeline
+func createTestFiles(n int) []*fs.FileInfo {
+	files := make([]*fs.FileInfo, n)
+	for i := range files {
+		files[i] = &fs.FileInfo{
+			Path:      "/project/file.go",
+			Relative:  "file.go",
+			Languages: "go",
+			Lines:     10,
+		}
+	}
+	return files
+}
+
+func TestPipeline_DefaultConfidence(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{
+			{Title: "Unscored", Line: 3, Severity: review.SeverityHigh},
+			{Title: "Scored", Line: 7, Severity: review.SeverityInfo, Confidence: 0.9},
+		},
+	}
+
+	config := review.DefaultConfig()
+	config.DefaultConfidence = 0.5
+
+	p, err := review.NewPipeline(config, stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	result, err := p.Run(context.Background(), createTestFiles(1))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.FileReviews) != 1 {
+		t.Fatalf("expected 1 file review, got %d", len(result.FileReviews))
+	}
+
+	for _, issue := range result.FileReviews[0].Issues {
+		switch issue.Title {
+		case "Unscored":
+			if issue.Confidence != 0.5 {
+				t.Errorf("unscored issue confidence = %v, want 0.5", issue.Confidence)
+			}
+		case "Scored":
+			if issue.Confidence != 0.9 {
+				t.Errorf("scored issue confidence = %v, want 0.9", issue.Confidence)
+			}
+		}
+	}
+}
+
+func TestPipeline_RunStream(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{{Title: "Long function", Line: 1, Severity: review.SeverityHigh}},
+	}
+
+	p, err := review.NewPipeline(review.DefaultConfig(), stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	stream := make(chan *review.FileReview)
+	streamed := make(chan int)
+	go func() {
+		count := 0
+		for range stream {
+			count++
+		}
+		streamed <- count
+	}()
+
+	result, err := p.RunStream(context.Background(), createTestFiles(5), stream)
+	if err != nil {
+		t.Fatalf("RunStream failed: %v", err)
+	}
+
+	if count := <-streamed; count != 5 {
+		t.Errorf("streamed %d file reviews, want 5", count)
+	}
+	if len(result.FileReviews) != 5 || result.TotalIssues != 5 {
+		t.Errorf("result has %d reviews and %d issues, want 5 and 5",
+			len(result.FileReviews), result.TotalIssues)
+	}
+}
+
+func TestPipeline_OnProgress(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{{Title: "Unhandled error", Line: 2, Severity: review.SeverityCritical}},
+	}
+
+	var calls, lastReviewed, lastTotal, lastCritical int
+	config := review.DefaultConfig()
+	config.OnProgress = func(reviewed, total int, result review.ReviewResult) {
+		calls++
+		lastReviewed, lastTotal = reviewed, total
+		lastCritical = result.CriticalCount
+	}
+
+	p, err := review.NewPipeline(config, stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	if _, err := p.Run(context.Background(), createTestFiles(3)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("progress called %d times, want 3", calls)
+	}
+	if lastReviewed != 3 || lastTotal != 3 || lastCritical != 3 {
+		t.Errorf("last progress = %d/%d with %d critical, want 3/3 with 3",
+			lastReviewed, lastTotal, lastCritical)
+	}
+}
+
+func TestPipeline_MinConfidence(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{
+			{Title: "Unscored", Line: 3, Severity: review.SeverityHigh},
+			{Title: "Likely", Line: 5, Severity: review.SeverityCritical, Confidence: 0.9},
+			{Title: "Doubtful", Line: 7, Severity: review.SeverityInfo, Confidence: 0.2},
+		},
+	}
+
+	config := review.DefaultConfig()
+	config.DefaultConfidence = 0.5
+	config.MinConfidence = 0.4
+
+	p, err := review.NewPipeline(config, stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	result, err := p.Run(context.Background(), createTestFiles(1))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// The unscored issue receives the default confidence and survives the filter
+	fileReview := result.FileReviews[0]
+	if len(fileReview.Issues) != 2 {
+		t.Fatalf("expected 2 issues after filtering, got %d", len(fileReview.Issues))
+	}
+	if result.TotalIssues != 2 || result.InfoCount != 0 {
+		t.Errorf("counts include filtered issues: total=%d info=%d", result.TotalIssues, result.InfoCount)
+	}
+
+	if len(fileReview.Suppressed) != 1 || fileReview.Suppressed[0].Issue.Title != "Doubtful" {
+		t.Errorf("suppressed = %+v, want [Doubtful]", fileReview.Suppressed)
+	}
+	if result.SuppressedCount != 1 || result.MinConfidence != 0.4 {
+		t.Errorf("suppressed count = %d, min confidence = %v", result.SuppressedCount, result.MinConfidence)
+	}
+}
+
+// TestPipeline_MinConfidenceEverySeverity checks that the filter applies to
+// critical issues as well, so a doubtful critical no longer fails the run
+func TestPipeline_MinConfidenceEverySeverity(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{
+			{Title: "Doubtful critical", Line: 3, Severity: review.SeverityCritical, Confidence: 0.3},
+			{Title: "Doubtful info", Line: 5, Severity: review.SeverityInfo, Confidence: 0.6},
+			{Title: "Likely info", Line: 7, Severity: review.SeverityInfo, Confidence: 0.9},
+		},
+	}
+
+	config := review.DefaultConfig()
+	config.MinConfidence = 0.7
+
+	p, err := review.NewPipeline(config, stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	result, err := p.Run(context.Background(), createTestFiles(2))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.CriticalCount != 0 || result.InfoCount != 2 || result.TotalIssues != 2 {
+		t.Errorf("counts = critical %d, info %d, total %d; want 0, 2, 2",
+			result.CriticalCount, result.InfoCount, result.TotalIssues)
+	}
+	if result.SuppressedCount != 4 {
+		t.Errorf("suppressed count = %d, want 4", result.SuppressedCount)
+	}
+	if code := output.DetermineExitCode(result); code != 0 {
+		t.Errorf("exit code = %d, want 0 once the critical issue is filtered", code)
+	}
+}
+
+func TestPipeline_DeduplicatesIssues(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{
+			{Title: "Unhandled error", Line: 3, Severity: review.SeverityCritical},
+			{Title: "Unhandled error", Line: 3, Severity: review.SeverityCritical},
+			{Title: "Long function", Line: 9, Severity: review.SeverityHigh},
+		},
+	}
+
+	p, err := review.NewPipeline(review.DefaultConfig(), stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	result, err := p.Run(context.Background(), createTestFiles(2))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.DuplicatesRemoved != 2 {
+		t.Errorf("DuplicatesRemoved = %d, want 2", result.DuplicatesRemoved)
+	}
+	if result.TotalIssues != 4 || result.CriticalCount != 2 {
+		t.Errorf("total = %d, critical = %d; want 4 and 2", result.TotalIssues, result.CriticalCount)
+	}
+}
+
+func TestPipeline_DeduplicatesMockIssues(t *testing.T) {
+	mock := reviewer.NewMockReviewer("mock",
+		reviewer.WithSeed(42),
+		reviewer.WithErrorRate(0),
+		reviewer.WithLatency(time.Microsecond, time.Microsecond),
+		reviewer.WithIssueRate(2.0),
+	)
+
+	p, err := review.NewPipeline(review.DefaultConfig(), mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	// The seeded mock draws each file's issues from its path, so distinct
+	// paths give every file its own issues and the run is repeatable
+	files := createTestFiles(1000)
+	for i, file := range files {
+		file.Path = fmt.Sprintf("/project/file%d.go", i)
+	}
+
+	result, err := p.Run(context.Background(), files)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	total := 0
+	for _, fileReview := range result.FileReviews {
+		seen := make(map[string]bool)
+		for _, issue := range fileReview.Issues {
+			fp := review.IssueFingerprint(issue.FilePath, issue.Title, issue.Line)
+			if seen[fp] {
+				t.Fatalf("duplicate issue %q at line %d survived", issue.Title, issue.Line)
+			}
+			seen[fp] = true
+		}
+		total += len(fileReview.Issues)
+	}
+
+	if total != result.TotalIssues {
+		t.Errorf("counted %d issues, result reports %d", total, result.TotalIssues)
+	}
+	if result.DuplicatesRemoved == 0 {
+		t.Error("expected the mock to report duplicates for the pipeline to remove")
+	}
+}
+
+func TestPipeline_FocusAreas(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{
+			{Title: "Hardcoded secret", Line: 1, Category: "security", Severity: review.SeverityCritical},
+			{Title: "Naming inconsistency", Line: 2, Category: "style", Severity: review.SeverityHigh},
+			{Title: "Slow loop", Line: 3, Category: "Performance", Severity: review.SeverityHigh},
+		},
+	}
+
+	config := review.DefaultConfig()
+	config.FocusAreas = []string{"security", "performance"}
+
+	p, err := review.NewPipeline(config, stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	result, err := p.Run(context.Background(), createTestFiles(1))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	fileReview := result.FileReviews[0]
+	if len(fileReview.Issues) != 2 || result.TotalIssues != 2 {
+		t.Fatalf("expected 2 focused issues, got %d", len(fileReview.Issues))
+	}
+	if len(fileReview.Suppressed) != 1 || fileReview.Suppressed[0].Reason != "outside focus" {
+		t.Errorf("suppressed = %+v, want the style issue outside focus", fileReview.Suppressed)
+	}
+}
+
+func TestPipeline_StartTime(t *testing.T) {
+	stub := &stubReviewer{}
+
+	p, err := review.NewPipeline(review.DefaultConfig(), stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	before := time.Now()
+	result, err := p.Run(context.Background(), createTestFiles(2))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if result.StartTime.IsZero() {
+		t.Fatal("StartTime was not set")
+	}
+	if result.StartTime.Before(before) || !result.StartTime.Before(result.EndTime) {
+		t.Errorf("StartTime %v not within run ending %v", result.StartTime, result.EndTime)
+	}
+	if got := result.EndTime.Sub(result.StartTime); got != result.Duration {
+		t.Errorf("Duration = %v, want EndTime - StartTime = %v", result.Duration, got)
+	}
+}
+
+func TestPipeline_RunChannel(t *testing.T) {
+	stub := &stubReviewer{
+		issues: []review.Issue{{Title: "Long function", Line: 1, Severity: review.SeverityHigh}},
+	}
+
+	var lastReviewed, lastTotal int
+	config := review.DefaultConfig()
+	config.OnProgress = func(reviewed, total int, result review.ReviewResult) {
+		lastReviewed, lastTotal = reviewed, total
+	}
+
+	p, err := review.NewPipeline(config, stub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	// Files trickle in as a scanner would produce them
+	files := make(chan *fs.FileInfo)
+	go func() {
+		defer close(files)
+		for _, file := range createTestFiles(20) {
+			files <- file
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	results := make(chan *review.FileReview)
+	streamed := make(chan int)
+	go func() {
+		count := 0
+		for range results {
+			count++
+		}
+		streamed <- count
+	}()
+
+	result, err := p.RunChannel(context.Background(), files, results)
+	if err != nil {
+		t.Fatalf("RunChannel failed: %v", err)
+	}
+
+	if count := <-streamed; count != 20 {
+		t.Errorf("streamed %d file reviews, want 20", count)
+	}
+	if result.TotalFiles != 20 || len(result.FileReviews) != 20 || result.TotalIssues != 20 {
+		t.Errorf("result has %d files, %d reviews and %d issues, want 20 each",
+			result.TotalFiles, len(result.FileReviews), result.TotalIssues)
+	}
+	if lastReviewed != 20 || lastTotal != 20 {
+		t.Errorf("last progress = %d/%d, want 20/20", lastReviewed, lastTotal)
+	}
+}
+
+func TestPipeline_RunChannel_Cancelled(t *testing.T) {
+	p, err := review.NewPipeline(review.DefaultConfig(), &stubReviewer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	// The channel is never closed, so only cancellation ends the run
+	files := make(chan *fs.FileInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := p.RunChannel(ctx, files, nil); err == nil {
+		t.Error("expected an error when the context is cancelled")
+	}
+}
+
+// TestPipeline_ConcurrentCollection exercises the collector alongside busy
+// workers, progress callbacks, streaming and a mid-run cancellation. Run it
+// with -race to check that result is only written by the collector.
+func TestPipeline_ConcurrentCollection(t *testing.T) {
+	mock := reviewer.NewMockReviewer("mock",
+		reviewer.WithSeed(7),
+		reviewer.WithErrorRate(0.2),
+		reviewer.WithLatency(time.Millisecond, 3*time.Millisecond),
+	)
+
+	var progressCalls int
+	config := review.DefaultConfig()
+	config.MaxWorkers = 8
+	config.MaxQueueSize = 4
+	config.OnProgress = func(reviewed, total int, result review.ReviewResult) {
+		progressCalls++
+	}
+
+	p, err := review.NewPipeline(config, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := make(chan *review.FileReview)
+	go func() {
+		seen := 0
+		for range stream {
+			// Cancel part way through while workers are still sending results
+			if seen++; seen == 20 {
+				cancel()
+			}
+		}
+	}()
+
+	done := make(chan struct{})
+	var result *review.ReviewResult
+	var runErr error
+	go func() {
+		defer close(done)
+		result, runErr = p.RunStream(ctx, createTestFiles(200), stream)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("pipeline did not finish after cancellation")
+	}
+
+	// The cancelled run still returns the reviews collected before it stopped
+	if !errors.Is(runErr, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", runErr)
+	}
+	if !result.Interrupted {
+		t.Error("result should be marked as interrupted")
+	}
+	if len(result.FileReviews) != progressCalls {
+		t.Errorf("collected %d file reviews with %d progress calls", len(result.FileReviews), progressCalls)
+	}
+	for _, fileReview := range result.FileReviews {
+		if fileReview.Error == context.Canceled.Error() {
+			t.Error("cancelled reviews should not be recorded as failures")
+		}
+	}
+}
+
+func TestPipeline_FilesProcessedMetric(t *testing.T) {
+	mock := reviewer.NewMockReviewer("mock",
+		reviewer.WithSeed(3),
+		reviewer.WithErrorRate(0.3),
+		reviewer.WithLatency(time.Millisecond, time.Millisecond),
+	)
+
+	config := review.DefaultConfig()
+	config.MaxRetries = 0
+
+	p, err := review.NewPipeline(config, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	const n = 25
+	if _, err := p.Run(context.Background(), createTestFiles(n)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Failed reviews are processed too
+	metrics := p.GetMetrics()
+	if metrics["files_processed"] != n {
+		t.Errorf("files_processed = %d, want %d", metrics["files_processed"], n)
+	}
+	if metrics["files_skipped"] != 0 {
+		t.Errorf("files_skipped = %d, want 0", metrics["files_skipped"])
+	}
+}
+
+// skippingReviewer skips every file
+type skippingReviewer struct{}
+
+func (skippingReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
+	return nil, review.ErrSkipped
+}
+
+func (skippingReviewer) Name() string {
+	return "skipping"
+}
+
+func TestPipeline_FilesSkippedMetric(t *testing.T) {
+	p, err := review.NewPipeline(review.DefaultConfig(), skippingReviewer{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	result, err := p.Run(context.Background(), createTestFiles(4))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	metrics := p.GetMetrics()
+	if metrics["files_processed"] != 4 || metrics["files_skipped"] != 4 || metrics["files_failed"] != 0 {
+		t.Errorf("metrics = %v, want 4 processed and skipped, none failed", metrics)
+	}
+	for _, fileReview := range result.FileReviews {
+		if fileReview.Error != "" {
+			t.Errorf("skipped file reported error %q", fileReview.Error)
+		}
+	}
+}
+
+// flakyReviewer fails the first review of each file and succeeds afterwards
+type flakyReviewer struct {
+	mu       sync.Mutex
+	attempts map[*fs.FileInfo]int
+}
+
+func (f *flakyReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.attempts[file]++
+	if f.attempts[file] == 1 {
+		return nil, errors.New("temporary failure")
+	}
+	return []review.Issue{{FilePath: file.Path, Title: "Found on retry", Line: 4, Severity: review.SeverityCritical}}, nil
+}
+
+func (f *flakyReviewer) Name() string {
+	return "flaky"
+}
+
+func TestPipeline_DeadLetterRetries(t *testing.T) {
+	flaky := &flakyReviewer{attempts: make(map[*fs.FileInfo]int)}
+
+	p, err := review.NewPipeline(review.DefaultConfig(), flaky)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	result, err := p.Run(context.Background(), createTestFiles(3))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Each retried file replaces its failed review
+	if len(result.FileReviews) != 3 {
+		t.Fatalf("got %d file reviews, want 3", len(result.FileReviews))
+	}
+	for _, fileReview := range result.FileReviews {
+		if fileReview.Error != "" || len(fileReview.Issues) != 1 {
+			t.Errorf("file review = %+v, want the issue found on retry", fileReview)
+		}
+	}
+	if result.ReviewedFiles != 3 || result.TotalIssues != 3 || result.CriticalCount != 3 {
+		t.Errorf("reviewed = %d, total = %d, critical = %d; want 3 each",
+			result.ReviewedFiles, result.TotalIssues, result.CriticalCount)
+	}
+}
+
+func TestPipeline_DeadLetterRetriesStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		reviewer review.Reviewer
+		wantErr  bool
+	}{
+		{"retry succeeds", &flakyReviewer{attempts: make(map[*fs.FileInfo]int)}, false},
+		{"retry fails", reviewer.NewMockReviewer("broken", reviewer.WithErrorRate(1)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := review.DefaultConfig()
+			config.MaxRetries = 1
+			p, err := review.NewPipeline(config, tt.reviewer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer p.Stop()
+
+			stream := make(chan *review.FileReview)
+			streamed := make(chan []*review.FileReview, 1)
+			go func() {
+				var reviews []*review.FileReview
+				for fileReview := range stream {
+					reviews = append(reviews, fileReview)
+				}
+				streamed <- reviews
+			}()
+
+			result, err := p.RunStream(context.Background(), createTestFiles(3), stream)
+			if err != nil {
+				t.Fatalf("RunStream failed: %v", err)
+			}
+
+			// Each file is streamed once, with its final review
+			reviews := <-streamed
+			if len(reviews) != 3 {
+				t.Fatalf("streamed %d file reviews, want 3", len(reviews))
+			}
+			for _, fileReview := range reviews {
+				if (fileReview.Error != "") != tt.wantErr {
+					t.Errorf("streamed review = %+v, want error %v", fileReview, tt.wantErr)
+				}
+			}
+			if !tt.wantErr && result.TotalIssues != 3 {
+				t.Errorf("total issues = %d, want 3", result.TotalIssues)
+			}
+		})
+	}
+}
+
+func TestPipeline_DeadLetterFile(t *testing.T) {
+	mock := reviewer.NewMockReviewer("broken",
+		reviewer.WithErrorRate(1),
+		reviewer.WithLatency(0, time.Microsecond),
+	)
+
+	dir := t.TempDir()
+	config := review.DefaultConfig()
+	config.MaxRetries = 1
+	config.DeadLetterFile = filepath.Join(dir, "run.jsonl")
+
+	p, err := review.NewPipeline(config, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	if _, err := p.Run(context.Background(), createTestFiles(2)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	// Each failure is recorded as it happens, and again after its failed retry
+	records, err := worker.LoadDeadLetters(config.DeadLetterFile)
+	if err != nil {
+		t.Fatalf("LoadDeadLetters failed: %v", err)
+	}
+	if len(records) != 4 {
+		t.Errorf("persisted %d records, want 4", len(records))
+	}
+
+	// The dump holds only the final queue
+	dump := filepath.Join(dir, "failures.jsonl")
+	if err := p.DumpDeadLetters(dump); err != nil {
+		t.Fatalf("DumpDeadLetters failed: %v", err)
+	}
+	records, err = worker.LoadDeadLetters(dump)
+	if err != nil {
+		t.Fatalf("LoadDeadLetters failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("dumped %d records, want 2", len(records))
+	}
+	for _, record := range records {
+		if record.Attempts != 2 || record.Error == "" {
+			t.Errorf("unexpected record: %+v", record)
+		}
+	}
+}
+
+func TestPipeline_TimeoutPerFile(t *testing.T) {
+	mock := reviewer.NewMockReviewer("slow",
+		reviewer.WithErrorRate(0),
+		reviewer.WithLatency(time.Second, time.Second),
+	)
+
+	config := review.DefaultConfig()
+	config.TimeoutPerFile = 20 * time.Millisecond
+	config.TimeoutPerLine = 0 // A fixed timeout instead of one scaled by length
+	config.MaxRetries = 0
+
+	p, err := review.NewPipeline(config, mock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	start := time.Now()
+	result, err := p.Run(context.Background(), createTestFiles(2))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("run took %v despite a 20ms per-file timeout", elapsed)
+	}
+	for _, fileReview := range result.FileReviews {
+		if fileReview.Error != "review timed out after 20ms" {
+			t.Errorf("file error = %q, want the configured timeout", fileReview.Error)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package review
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// inlineDirective matches a "// scanr:ignore" or "# scanr:ignore" comment
+	// and captures what follows it
+	inlineDirective = regexp.MustCompile(`(?://|#)\s*scanr:ignore\b(.*)`)
+	// issueCode matches an issue code listed after a directive, e.g. SEC001
+	issueCode = regexp.MustCompile(`^[A-Z][A-Z0-9_-]*$`)
+)
+
+// suppression is a scanr:ignore directive: it silences every issue, or only
+// the listed codes, on its own line and the line below
+type suppression struct {
+	codes []string
+}
+
+// covers reports whether the directive silences issue
+func (s suppression) covers(issue Issue) bool {
+	return len(s.codes) == 0 || slices.Contains(s.codes, issue.Code)
+}
+
+// parseSuppressions finds the scanr:ignore directives in content, keyed by
+// line number. Codes follow the directive separated by spaces or commas; the
+// first word that is not an upper-case code ends the list, so a reason can
+// follow: "// scanr:ignore SEC001 test fixture".
+func parseSuppressions(content []byte) map[int]suppression {
+	var suppressions map[int]suppression
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if !strings.Contains(text, "scanr:ignore") {
+			continue
+		}
+		match := inlineDirective.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+
+		var s suppression
+		for _, field := range strings.FieldsFunc(match[1], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			if !issueCode.MatchString(field) {
+				break
+			}
+			s.codes = append(s.codes, field)
+		}
+
+		if suppressions == nil {
+			suppressions = make(map[int]suppression)
+		}
+		suppressions[line] = s
+	}
+
+	return suppressions
+}
+
+// readSuppressions parses the directives of the file at path. An unreadable
+// file has none.
+func readSuppressions(path string) map[int]suppression {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return parseSuppressions(content)
+}
+
+// filterInlineSuppressed splits issues into those kept and those silenced by
+// a directive on their line or the line above
+func filterInlineSuppressed(issues []Issue, suppressions map[int]suppression) (kept, silenced []Issue) {
+	if len(suppressions) == 0 {
+		return issues, nil
+	}
+
+	kept = make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if issue.Line > 0 && (coveredBy(suppressions, issue.Line, issue) || coveredBy(suppressions, issue.Line-1, issue)) {
+			silenced = append(silenced, issue)
+		} else {
+			kept = append(kept, issue)
+		}
+	}
+	return kept, silenced
+}
+
+// coveredBy reports whether a directive on line silences issue
+func coveredBy(suppressions map[int]suppression, line int, issue Issue) bool {
+	s, ok := suppressions[line]
+	return ok && s.covers(issue)
+}
@@ -0,0 +1,94 @@
+package review
+
+import (
+	"os"
+	"path/filepath"
+	"scanr/internal/fs"
+	"testing"
+)
+
+func TestParseSuppressions(t *testing.T) {
+	content := []byte(`package main
+
+// scanr:ignore
+var key = "secret"
+
+func main() {
+	run() // scanr:ignore SEC001,ERR002 known false positive
+	x := 1 // scanr:ignored is not a directive
+}
+`)
+	// A Python comment works too
+	content = append(content, "value = eval(data)  # scanr:ignore SEC003\n"...)
+
+	got := parseSuppressions(content)
+	if len(got) != 3 {
+		t.Fatalf("got %d directives, want 3: %+v", len(got), got)
+	}
+	if s, ok := got[3]; !ok || len(s.codes) != 0 {
+		t.Errorf("line 3 = %+v, want a directive for every code", s)
+	}
+	if s := got[7]; len(s.codes) != 2 || s.codes[0] != "SEC001" || s.codes[1] != "ERR002" {
+		t.Errorf("line 7 codes = %v, want [SEC001 ERR002]", s.codes)
+	}
+	if s := got[10]; len(s.codes) != 1 || s.codes[0] != "SEC003" {
+		t.Errorf("line 10 codes = %v, want [SEC003]", s.codes)
+	}
+}
+
+func TestFilterInlineSuppressed(t *testing.T) {
+	suppressions := map[int]suppression{
+		3: {},
+		7: {codes: []string{"SEC001"}},
+	}
+	issues := []Issue{
+		{Title: "on the directive line", Line: 3},
+		{Title: "below the directive", Line: 4},
+		{Title: "two lines below", Line: 5},
+		{Title: "matching code", Line: 7, Code: "SEC001"},
+		{Title: "other code", Line: 7, Code: "ERR002"},
+		{Title: "no line", Line: 0},
+	}
+
+	kept, silenced := filterInlineSuppressed(issues, suppressions)
+
+	var keptTitles []string
+	for _, issue := range kept {
+		keptTitles = append(keptTitles, issue.Title)
+	}
+	if len(silenced) != 3 || len(kept) != 3 {
+		t.Fatalf("silenced %d, kept %v; want 3 silenced", len(silenced), keptTitles)
+	}
+	want := []string{"two lines below", "other code", "no line"}
+	for i, title := range want {
+		if keptTitles[i] != title {
+			t.Errorf("kept[%d] = %q, want %q", i, keptTitles[i], title)
+		}
+	}
+}
+
+func TestApplyIssues_InlineSuppression(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	content := "package main\n\n// scanr:ignore\nvar key = \"secret\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &pipeline{config: DefaultConfig(), metrics: &metrics{}}
+	fileReview := FileReview{File: &fs.FileInfo{Path: path}}
+	result := &ReviewResult{}
+	p.applyIssues(&fileReview, []Issue{
+		{FilePath: path, Title: "Hardcoded secret", Line: 4, Severity: SeverityCritical},
+		{FilePath: path, Title: "Package comment", Line: 1, Severity: SeverityInfo},
+	}, result)
+
+	if len(fileReview.Issues) != 1 || fileReview.Issues[0].Title != "Package comment" {
+		t.Errorf("issues = %+v, want only the package comment", fileReview.Issues)
+	}
+	if len(fileReview.Suppressed) != 1 || fileReview.Suppressed[0].Reason != "inline comment" {
+		t.Errorf("suppressed = %+v, want the secret with reason inline comment", fileReview.Suppressed)
+	}
+	if result.SuppressedCount != 1 || result.CriticalCount != 0 || result.TotalIssues != 1 {
+		t.Errorf("counts: suppressed %d, critical %d, total %d", result.SuppressedCount, result.CriticalCount, result.TotalIssues)
+	}
+}
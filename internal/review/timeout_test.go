@@ -0,0 +1,29 @@
+package review
+
+import (
+	"scanr/internal/fs"
+	"testing"
+	"time"
+)
+
+func TestConfig_FileTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		perLine time.Duration
+		lines   int
+		want    time.Duration
+	}{
+		{"scales with lines", 30 * time.Millisecond, 1000, 30 * time.Second},
+		{"never below the minimum", 30 * time.Millisecond, 20, MinTimeout},
+		{"fixed when not scaling", 0, 1000, 45 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{TimeoutPerFile: 45 * time.Second, TimeoutPerLine: tt.perLine}
+			if got := config.fileTimeout(&fs.FileInfo{Lines: tt.lines}); got != tt.want {
+				t.Errorf("fileTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package review
 
 import (
 	"context"
+	"errors"
 	internalfs "scanr/internal/fs"
 	"time"
 )
 
+// ErrSkipped is returned by a reviewer that deliberately did not review a
+// file, for example because a cached result was reused or it does not support
+// the file's language. Skipped files are counted separately from failures.
+var ErrSkipped = errors.New("file skipped")
+
 type Severity string
 
 const (
@@ -14,38 +20,57 @@ const (
 	SeverityInfo     Severity = "info"
 )
 
+// Categories lists the issue categories reviewers report
+var Categories = []string{
+	"security", "reliability", "performance", "maintainability",
+	"readability", "style", "documentation",
+}
+
 type Issue struct {
-	FilePath    string    `json:"file_path"`
-	Line        int       `json:"line,omitempty"`
-	Column      int       `json:"column,omitempty"`
-	Code        string    `json:"code,omitempty"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Severity    Severity  `json:"severity"`
-	Category    string    `json:"category,omitempty"`
-	Suggestions []string  `json:"suggestions,omitempty"`
-	Confidence  float64   `json:"confidence,omitempty"`
-	FoundAt     time.Time `json:"found_at"`
+	FilePath      string    `json:"file_path"`
+	Line          int       `json:"line,omitempty"`
+	Column        int       `json:"column,omitempty"`
+	Code          string    `json:"code,omitempty"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	Severity      Severity  `json:"severity"`
+	Category      string    `json:"category,omitempty"`
+	Suggestions   []string  `json:"suggestions,omitempty"`
+	FixSuggestion string    `json:"fix_suggestion,omitempty"`
+	Confidence    float64   `json:"confidence,omitempty"`
+	FoundAt       time.Time `json:"found_at"`
+}
+
+// SuppressedIssue is an issue hidden from the results, with the reason it was removed
+type SuppressedIssue struct {
+	Issue  Issue  `json:"issue"`
+	Reason string `json:"reason"`
 }
 
 type FileReview struct {
-	File     *internalfs.FileInfo `json:"file"`
-	Issues   []Issue              `json:"issues"`
-	Duration time.Duration        `json:"duration_ms"`
-	Error    string               `json:"error,omitempty"`
+	File       *internalfs.FileInfo `json:"file"`
+	Issues     []Issue              `json:"issues"`
+	Suppressed []SuppressedIssue    `json:"suppressed,omitempty"`
+	Duration   time.Duration        `json:"duration_ms"`
+	Error      string               `json:"error,omitempty"`
 }
 
 type ReviewResult struct {
-	TotalFiles    int           `json:"total_files"`
-	ReviewedFiles int           `json:"reviewed_files"`
-	TotalIssues   int           `json:"total_issues"`
-	CriticalCount int           `json:"critical_count"`
-	WarningCount  int           `json:"warning_count"`
-	InfoCount     int           `json:"info_count"`
-	FileReviews   []FileReview  `json:"file_reviews"`
-	Duration      time.Duration `json:"total_duration_ms"`
-	StartTime     time.Time     `json:"start_time"`
-	EndTime       time.Time     `json:"end_time"`
+	TotalFiles        int           `json:"total_files"`
+	ReviewedFiles     int           `json:"reviewed_files"`
+	TotalIssues       int           `json:"total_issues"`
+	CriticalCount     int           `json:"critical_count"`
+	WarningCount      int           `json:"warning_count"`
+	InfoCount         int           `json:"info_count"`
+	SuppressedCount   int           `json:"suppressed_count"`
+	MinConfidence     float64       `json:"min_confidence,omitempty"`
+	DuplicatesRemoved int           `json:"duplicates_removed"`
+	FileReviews       []FileReview  `json:"file_reviews"`
+	Duration          time.Duration `json:"total_duration_ms"`
+	StartTime         time.Time     `json:"start_time"`
+	EndTime           time.Time     `json:"end_time"`
+	// Interrupted is set when the run was cancelled before every file was reviewed
+	Interrupted bool `json:"interrupted,omitempty"`
 }
 
 // interface for reviewing files
@@ -55,6 +80,24 @@ type Reviewer interface {
 }
 
 type Pipeline interface {
+	// Run reviews files and returns the result. When ctx is cancelled it
+	// returns the reviews finished so far together with ctx.Err().
 	Run(ctx context.Context, files []*internalfs.FileInfo) (*ReviewResult, error)
+	// RunStream behaves like Run but also sends each FileReview to results as
+	// soon as it is ready. results is closed when the run finishes.
+	RunStream(ctx context.Context, files []*internalfs.FileInfo, results chan<- *FileReview) (*ReviewResult, error)
+	// RunChannel reviews files as they arrive on files until it is closed, so
+	// reviews can start while a scan is still running. results may be nil;
+	// otherwise it receives each FileReview and is closed when the run finishes.
+	RunChannel(ctx context.Context, files <-chan *internalfs.FileInfo, results chan<- *FileReview) (*ReviewResult, error)
 	Stop() error
+	// Stats returns the worker pool statistics: capacity, queue_size, active,
+	// total_tasks, failed_tasks and retried_tasks
+	Stats() map[string]int64
+	// GetMetrics returns the pipeline counters: files_processed, files_failed,
+	// files_retried, files_skipped, total_issues and total_duration
+	GetMetrics() map[string]int64
+	// DumpDeadLetters writes the files that still failed after retries to
+	// path as JSON lines, replacing the file
+	DumpDeadLetters(path string) error
 }
@@ -0,0 +1,95 @@
+package version
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// Build information, set at build time with
+//
+//	go build -ldflags "-X scanr/internal/version.Version=v1.2.0 \
+//	  -X scanr/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X scanr/internal/version.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd/scanr
+//
+// Values left empty are filled from the module and VCS information Go embeds
+// in the binary.
+var (
+	Version string
+	Commit  string
+	Date    string
+)
+
+// unknown is reported for build details that are not available
+const unknown = "unknown"
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	buildInfo, ok := debug.ReadBuildInfo()
+	return resolve(Version, Commit, Date, buildInfo, ok)
+}
+
+// resolve prefers the ldflags values and falls back to the embedded build info
+func resolve(version, commit, date string, buildInfo *debug.BuildInfo, ok bool) Info {
+	info := Info{Version: version, Commit: commit, Date: date}
+
+	if ok {
+		info.GoVersion = buildInfo.GoVersion
+		if info.Version == "" && buildInfo.Main.Version != "" && buildInfo.Main.Version != "(devel)" {
+			info.Version = buildInfo.Main.Version
+		}
+
+		modified := false
+		for _, setting := range buildInfo.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = shortRevision(setting.Value)
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = setting.Value
+				}
+			case "vcs.modified":
+				modified = setting.Value == "true"
+			}
+		}
+		if modified && commit == "" && info.Commit != "" {
+			info.Commit += "-dirty"
+		}
+	}
+
+	if info.Version == "" {
+		info.Version = "dev"
+	}
+	if info.Commit == "" {
+		info.Commit = unknown
+	}
+	if info.Date == "" {
+		info.Date = unknown
+	}
+	if info.GoVersion == "" {
+		info.GoVersion = unknown
+	}
+	return info
+}
+
+// shortRevision abbreviates a commit hash the way git does by default
+func shortRevision(revision string) string {
+	if len(revision) > 12 {
+		return revision[:12]
+	}
+	return revision
+}
+
+// String formats the build information for 'scanr version'
+func (i Info) String() string {
+	return fmt.Sprintf("scanr %s (commit %s, built %s, %s)", i.Version, i.Commit, i.Date, i.GoVersion)
+}
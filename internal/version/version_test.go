@@ -0,0 +1,56 @@
+package version
+
+import (
+	"runtime/debug"
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	buildInfo := &debug.BuildInfo{
+		GoVersion: "go1.25.5",
+		Main:      debug.Module{Path: "scanr", Version: "v1.4.0"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123"},
+			{Key: "vcs.time", Value: "2026-10-01T12:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	tests := []struct {
+		name                  string
+		version, commit, date string
+		buildInfo             *debug.BuildInfo
+		ok                    bool
+		want                  Info
+	}{
+		{
+			name:    "ldflags take precedence",
+			version: "v2.0.0", commit: "abc1234", date: "2026-10-17",
+			buildInfo: buildInfo, ok: true,
+			want: Info{Version: "v2.0.0", Commit: "abc1234", Date: "2026-10-17", GoVersion: "go1.25.5"},
+		},
+		{
+			name:      "build info fallback",
+			buildInfo: buildInfo, ok: true,
+			want: Info{Version: "v1.4.0", Commit: "0123456789ab-dirty", Date: "2026-10-01T12:00:00Z", GoVersion: "go1.25.5"},
+		},
+		{
+			name:      "development build",
+			buildInfo: &debug.BuildInfo{GoVersion: "go1.25.5", Main: debug.Module{Version: "(devel)"}}, ok: true,
+			want: Info{Version: "dev", Commit: unknown, Date: unknown, GoVersion: "go1.25.5"},
+		},
+		{
+			name: "no build info",
+			want: Info{Version: "dev", Commit: unknown, Date: unknown, GoVersion: unknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolve(tt.version, tt.commit, tt.date, tt.buildInfo, tt.ok)
+			if got != tt.want {
+				t.Errorf("resolve() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,12 @@
 package worker
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 )
@@ -14,12 +19,34 @@ type DeadLetter struct {
 	Attempts  int
 }
 
+// DeadLetterRecord is the persisted form of a dead letter, one JSON object
+// per line
+type DeadLetterRecord struct {
+	Path      string    `json:"path"`
+	Error     string    `json:"error"`
+	Attempts  int       `json:"attempts"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Record converts a dead letter to its persisted form
+func (dl DeadLetter) Record() DeadLetterRecord {
+	record := DeadLetterRecord{Attempts: dl.Attempts, Timestamp: dl.Timestamp}
+	if dl.Task.File != nil {
+		record.Path = dl.Task.File.Path
+	}
+	if dl.Error != nil {
+		record.Error = dl.Error.Error()
+	}
+	return record
+}
+
 // DeadLetterQueue manages failed tasks that can be retried or logged
 type DeadLetterQueue struct {
-	items     []DeadLetter
-	mu        sync.RWMutex
-	maxSize   int
-	onDiscard func(dl DeadLetter)
+	items       []DeadLetter
+	mu          sync.RWMutex
+	maxSize     int
+	onDiscard   func(dl DeadLetter)
+	persistPath string
 }
 
 // NewDeadLetterQueue creates a new dead letter queue
@@ -28,8 +55,10 @@ func NewDeadLetterQueue(maxSize int) *DeadLetterQueue {
 		items:   make([]DeadLetter, 0, maxSize),
 		maxSize: maxSize,
 		onDiscard: func(dl DeadLetter) {
-			fmt.Printf("Discarded dead letter after %d attempts: %v\n",
-				dl.Attempts, dl.Error)
+			slog.Warn("discarded dead letter",
+				slog.Int("attempts", dl.Attempts),
+				slog.Any("error", dl.Error),
+			)
 		},
 	}
 }
@@ -54,6 +83,12 @@ func (q *DeadLetterQueue) Push(task Task, err error, attempts int) {
 	}
 
 	q.items = append(q.items, dl)
+
+	if q.persistPath != "" {
+		if err := appendDeadLetters(q.persistPath, []DeadLetterRecord{dl.Record()}); err != nil {
+			slog.Warn("failed to persist dead letter", slog.String("path", q.persistPath), slog.Any("error", err))
+		}
+	}
 }
 
 func (q *DeadLetterQueue) Pop() (DeadLetter, bool) {
@@ -111,3 +146,74 @@ func (q *DeadLetterQueue) SetDiscardHandler(handler func(DeadLetter)) {
 	defer q.mu.Unlock()
 	q.onDiscard = handler
 }
+
+// SetPersistPath makes every Push append a record to the file at path, so
+// failures survive a crash. The file and its directory are created on the
+// first Push. A task pushed back after a failed retry is recorded again with
+// its new attempt count. An empty path turns persistence off.
+func (q *DeadLetterQueue) SetPersistPath(path string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.persistPath = path
+}
+
+// Dump writes the records of every queued item to path, replacing the file
+func (q *DeadLetterQueue) Dump(path string) error {
+	items := q.Items()
+	records := make([]DeadLetterRecord, len(items))
+	for i, dl := range items {
+		records[i] = dl.Record()
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to replace %s: %v", path, err)
+	}
+	return appendDeadLetters(path, records)
+}
+
+// LoadDeadLetters reads the records written by a persisted queue or by Dump
+func LoadDeadLetters(path string) ([]DeadLetterRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []DeadLetterRecord
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record DeadLetterRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid dead letter: %v", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return records, nil
+}
+
+// appendDeadLetters appends records to path as JSON lines
+func appendDeadLetters(path string, records []DeadLetterRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(file)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	return file.Close()
+}
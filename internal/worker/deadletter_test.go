@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"scanr/internal/fs"
+)
+
+func TestDeadLetterQueue_Persist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "deadletters", "run.jsonl")
+
+	q := NewDeadLetterQueue(1)
+	var discarded []DeadLetter
+	q.SetDiscardHandler(func(dl DeadLetter) {
+		discarded = append(discarded, dl)
+	})
+	q.SetPersistPath(path)
+
+	q.Push(Task{File: &fs.FileInfo{Path: "/project/a.go"}}, errors.New("timeout"), 1)
+	q.Push(Task{File: &fs.FileInfo{Path: "/project/b.go"}}, errors.New("rate limited"), 3)
+
+	// The discard handler still runs when the queue is full
+	if len(discarded) != 1 || discarded[0].Task.File.Path != "/project/a.go" {
+		t.Errorf("discarded = %v, want a.go", discarded)
+	}
+
+	// Every push is persisted, including the discarded one
+	records, err := LoadDeadLetters(path)
+	if err != nil {
+		t.Fatalf("LoadDeadLetters failed: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("loaded %d records, want 2", len(records))
+	}
+	if records[1].Path != "/project/b.go" || records[1].Error != "rate limited" ||
+		records[1].Attempts != 3 || records[1].Timestamp.IsZero() {
+		t.Errorf("unexpected record: %+v", records[1])
+	}
+}
+
+func TestDeadLetterQueue_Dump(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "failures.jsonl")
+	if err := os.WriteFile(path, []byte("stale\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	q := NewDeadLetterQueue(10)
+	q.Push(Task{File: &fs.FileInfo{Path: "/project/a.go"}}, errors.New("timeout"), 2)
+
+	if err := q.Dump(path); err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+
+	// Dump replaces the file with the queue contents
+	records, err := LoadDeadLetters(path)
+	if err != nil {
+		t.Fatalf("LoadDeadLetters failed: %v", err)
+	}
+	if len(records) != 1 || records[0].Path != "/project/a.go" || records[0].Attempts != 2 {
+		t.Errorf("unexpected records: %+v", records)
+	}
+
+	if _, err := LoadDeadLetters(filepath.Join(t.TempDir(), "missing.jsonl")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
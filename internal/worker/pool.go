@@ -17,6 +17,23 @@ var (
 	ErrInvalidCapacity = errors.New("invalid worker capacity")
 )
 
+// DefaultTaskTimeout bounds each task unless WithTaskTimeout or
+// SetTaskTimeout changes it
+const DefaultTaskTimeout = 30 * time.Second
+
+// Option configures a WorkerPool at creation
+type Option func(*WorkerPool)
+
+// WithTaskTimeout sets how long the task for each file may run, so larger
+// files can be given longer. A nil func keeps DefaultTaskTimeout.
+func WithTaskTimeout(timeout func(file *fs.FileInfo) time.Duration) Option {
+	return func(p *WorkerPool) {
+		if timeout != nil {
+			p.taskTimeout = timeout
+		}
+	}
+}
+
 // Task represents a review task to be processed
 type Task struct {
 	ID     int
@@ -42,17 +59,21 @@ type WorkerPool struct {
 	stopChan      chan struct{}
 	stopped       atomic.Bool
 	wg            sync.WaitGroup
-	mu            sync.RWMutex
+	mu            sync.RWMutex // guards capacity, quits, ctx and workerFunc
+	quits         []chan struct{}
+	ctx           context.Context
+	workerFunc    WorkerFunc
 	activeWorkers atomic.Int32
 	totalTasks    atomic.Int64
 	failedTasks   atomic.Int64
 	retriedTasks  atomic.Int64
+	taskTimeout   func(file *fs.FileInfo) time.Duration
 }
 
 // WorkerFunc is the function that processes a task
 type WorkerFunc func(ctx context.Context, file *fs.FileInfo) (interface{}, error)
 
-func NewWorkerPool(capacity int, queueSize int) (*WorkerPool, error) {
+func NewWorkerPool(capacity int, queueSize int, opts ...Option) (*WorkerPool, error) {
 	if capacity <= 0 {
 		return nil, ErrInvalidCapacity
 	}
@@ -60,26 +81,93 @@ func NewWorkerPool(capacity int, queueSize int) (*WorkerPool, error) {
 		queueSize = capacity * 2
 	}
 
-	return &WorkerPool{
-		capacity:  capacity,
-		taskQueue: make(chan Task, queueSize),
-		stopChan:  make(chan struct{}),
-	}, nil
+	p := &WorkerPool{
+		capacity:    capacity,
+		taskQueue:   make(chan Task, queueSize),
+		stopChan:    make(chan struct{}),
+		taskTimeout: fixedTimeout(DefaultTaskTimeout),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p, nil
+}
+
+// fixedTimeout gives every file the same timeout
+func fixedTimeout(timeout time.Duration) func(*fs.FileInfo) time.Duration {
+	return func(*fs.FileInfo) time.Duration {
+		return timeout
+	}
+}
+
+// SetTaskTimeout gives every task the same timeout. Call it before Start;
+// non-positive values are ignored.
+func (p *WorkerPool) SetTaskTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		p.taskTimeout = fixedTimeout(timeout)
+	}
 }
 
 func (p *WorkerPool) Start(ctx context.Context, workerFunc WorkerFunc) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.stopped.Load() {
 		return ErrPoolStopped
 	}
 
-	for i := 0; i < p.capacity; i++ {
-		p.wg.Add(1)
-		go p.worker(ctx, workerFunc, i)
+	p.ctx = ctx
+	p.workerFunc = workerFunc
+	for len(p.quits) < p.capacity {
+		p.spawnWorker()
 	}
 
 	return nil
 }
 
+// Resize changes the number of workers while the pool runs. Growing starts
+// new workers at once; shrinking lets each retired worker finish its current
+// task first. Queued tasks are never dropped. Before Start it only sets the
+// number of workers Start will launch.
+func (p *WorkerPool) Resize(n int) error {
+	if n <= 0 {
+		return ErrInvalidCapacity
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopped.Load() {
+		return ErrPoolStopped
+	}
+
+	p.capacity = n
+	if p.workerFunc == nil {
+		return nil // Not started yet
+	}
+
+	for len(p.quits) < n {
+		p.spawnWorker()
+	}
+	for len(p.quits) > n {
+		last := len(p.quits) - 1
+		close(p.quits[last])
+		p.quits = p.quits[:last]
+	}
+
+	return nil
+}
+
+// spawnWorker starts one more worker. The caller must hold p.mu.
+func (p *WorkerPool) spawnWorker() {
+	quit := make(chan struct{})
+	id := len(p.quits)
+	p.quits = append(p.quits, quit)
+
+	p.wg.Add(1)
+	go p.worker(p.ctx, p.workerFunc, id, quit)
+}
+
 func (p *WorkerPool) Submit(ctx context.Context, taskID int, file *fs.FileInfo, resultChan chan<- TaskResult) error {
 	if p.stopped.Load() {
 		return ErrPoolStopped
@@ -101,6 +189,27 @@ func (p *WorkerPool) Submit(ctx context.Context, taskID int, file *fs.FileInfo,
 	}
 }
 
+// SubmitWithBackpressure submits a task, blocking while the queue is full
+// instead of returning ErrPoolBusy. It returns early if the context is cancelled.
+func (p *WorkerPool) SubmitWithBackpressure(ctx context.Context, taskID int, file *fs.FileInfo, resultChan chan<- TaskResult) error {
+	if p.stopped.Load() {
+		return ErrPoolStopped
+	}
+
+	select {
+	case p.taskQueue <- Task{
+		ID:     taskID,
+		File:   file,
+		Result: resultChan,
+		Ctx:    ctx,
+	}:
+		p.totalTasks.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // SubmitBatch submits multiple tasks to the worker pool
 func (p *WorkerPool) SubmitBatch(ctx context.Context, files []*fs.FileInfo, resultChan chan<- TaskResult) error {
 	for i, file := range files {
@@ -128,8 +237,12 @@ func (p *WorkerPool) QueueSize() int {
 
 // Stats returns pool statistics
 func (p *WorkerPool) Stats() map[string]int64 {
+	p.mu.RLock()
+	capacity := p.capacity
+	p.mu.RUnlock()
+
 	return map[string]int64{
-		"capacity":      int64(p.capacity),
+		"capacity":      int64(capacity),
 		"queue_size":    int64(len(p.taskQueue)),
 		"active":        int64(p.ActiveWorkers()),
 		"total_tasks":   p.totalTasks.Load(),
@@ -140,12 +253,16 @@ func (p *WorkerPool) Stats() map[string]int64 {
 
 // Stop stops the worker pool gracefully
 func (p *WorkerPool) Stop() error {
+	// Holding mu keeps Resize from adding workers once stopping has begun
+	p.mu.Lock()
 	if p.stopped.Swap(true) {
+		p.mu.Unlock()
 		return nil // Already stopped
 	}
 
 	close(p.stopChan)
 	close(p.taskQueue) // Signal workers to stop by closing the queue
+	p.mu.Unlock()
 
 	// Wait for all workers to finish
 	done := make(chan struct{})
@@ -163,28 +280,33 @@ func (p *WorkerPool) Stop() error {
 	}
 }
 
-// safeSend attempts to send a result without panicking if channel is closed
-func (p *WorkerPool) safeSend(resultChan chan<- TaskResult, result TaskResult) {
-	defer func() {
-		if r := recover(); r != nil {
-			// Channel was closed, silently ignore
-		}
-	}()
-
-	resultChan <- result
+// Wait blocks until every worker has exited. Call it after Stop and before
+// closing result channels so no in-flight result is lost.
+func (p *WorkerPool) Wait() {
+	p.wg.Wait()
 }
 
-// worker is the goroutine that processes tasks
-func (p *WorkerPool) worker(ctx context.Context, workerFunc WorkerFunc, id int) {
+// worker is the goroutine that processes tasks until the queue is closed or
+// quit is closed by Resize
+func (p *WorkerPool) worker(ctx context.Context, workerFunc WorkerFunc, id int, quit <-chan struct{}) {
 	defer p.wg.Done()
 
 	for {
+		// A retired worker takes no further tasks, even when one is waiting
+		select {
+		case <-quit:
+			return
+		default:
+		}
+
 		select {
 		case task, ok := <-p.taskQueue:
 			if !ok {
 				return // Queue was closed
 			}
 			p.processTask(ctx, task, workerFunc, id)
+		case <-quit:
+			return
 		}
 	}
 }
@@ -195,7 +317,8 @@ func (p *WorkerPool) processTask(ctx context.Context, task Task, workerFunc Work
 	defer p.activeWorkers.Add(-1)
 
 	// Merge contexts
-	mergedCtx, cancel := context.WithTimeout(task.Ctx, 30*time.Second)
+	timeout := p.taskTimeout(task.File)
+	mergedCtx, cancel := context.WithTimeout(task.Ctx, timeout)
 	defer cancel()
 
 	// Process the task
@@ -206,30 +329,30 @@ func (p *WorkerPool) processTask(ctx context.Context, task Task, workerFunc Work
 		// Context was cancelled or timed out
 		if errors.Is(mergedCtx.Err(), context.DeadlineExceeded) {
 			p.failedTasks.Add(1)
-			p.safeSend(task.Result, TaskResult{
+			task.Result <- TaskResult{
 				TaskID: task.ID,
 				File:   task.File,
-				Error:  fmt.Errorf("review timed out after 30 seconds"),
+				Error:  fmt.Errorf("review timed out after %s", timeout),
 				Retry:  true,
-			})
+			}
 		} else {
 			p.failedTasks.Add(1)
-			p.safeSend(task.Result, TaskResult{
+			task.Result <- TaskResult{
 				TaskID: task.ID,
 				File:   task.File,
 				Error:  mergedCtx.Err(),
 				Retry:  false,
-			})
+			}
 		}
 	default:
 		// Send result
-		p.safeSend(task.Result, TaskResult{
+		task.Result <- TaskResult{
 			TaskID: task.ID,
 			File:   task.File,
 			Issues: issues,
 			Error:  err,
 			Retry:  err != nil, // Retry on error
-		})
+		}
 
 		if err != nil {
 			p.failedTasks.Add(1)
@@ -277,3 +277,275 @@ func TestWorkerPool_Stats(t *testing.T) {
 		t.Error("expected some active workers")
 	}
 }
+
+func TestWorkerPool_DeliversAllResults(t *testing.T) {
+	const numTasks = 500
+
+	pool, err := NewWorkerPool(8, numTasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	workerFunc := func(ctx context.Context, file *fs.FileInfo) (interface{}, error) {
+		time.Sleep(time.Millisecond)
+		return nil, nil
+	}
+	if err := pool.Start(ctx, workerFunc); err != nil {
+		t.Fatal(err)
+	}
+
+	resultChan := make(chan TaskResult, numTasks)
+	for i := 0; i < numTasks; i++ {
+		if err := pool.Submit(ctx, i, &fs.FileInfo{Path: "test.go"}, resultChan); err != nil {
+			t.Fatalf("failed to submit task %d: %v", i, err)
+		}
+	}
+
+	// Same shutdown order as the pipeline: stop, wait, then close
+	pool.Stop()
+	pool.Wait()
+	close(resultChan)
+
+	seen := make(map[int]bool)
+	for result := range resultChan {
+		seen[result.TaskID] = true
+	}
+
+	if len(seen) != numTasks {
+		t.Errorf("collected %d results, want %d", len(seen), numTasks)
+	}
+}
+
+func TestWorkerPool_SubmitWithBackpressure(t *testing.T) {
+	const numTasks = 50
+
+	// A queue far smaller than the task count forces submissions to wait
+	pool, err := NewWorkerPool(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	workerFunc := func(ctx context.Context, file *fs.FileInfo) (interface{}, error) {
+		time.Sleep(2 * time.Millisecond)
+		return nil, nil
+	}
+	if err := pool.Start(ctx, workerFunc); err != nil {
+		t.Fatal(err)
+	}
+
+	resultChan := make(chan TaskResult, numTasks)
+	for i := 0; i < numTasks; i++ {
+		if err := pool.SubmitWithBackpressure(ctx, i, &fs.FileInfo{Path: "test.go"}, resultChan); err != nil {
+			t.Fatalf("failed to submit task %d: %v", i, err)
+		}
+	}
+
+	pool.Stop()
+	pool.Wait()
+	close(resultChan)
+
+	seen := make(map[int]bool)
+	for result := range resultChan {
+		seen[result.TaskID] = true
+	}
+
+	if len(seen) != numTasks {
+		t.Errorf("collected %d results, want %d", len(seen), numTasks)
+	}
+}
+
+func TestWorkerPool_SubmitWithBackpressure_Cancelled(t *testing.T) {
+	pool, err := NewWorkerPool(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := make(chan struct{})
+	workerFunc := func(ctx context.Context, file *fs.FileInfo) (interface{}, error) {
+		<-block
+		return nil, nil
+	}
+	if err := pool.Start(context.Background(), workerFunc); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		close(block)
+		pool.Stop()
+		pool.Wait()
+	}()
+
+	resultChan := make(chan TaskResult, 4)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// One task occupies the worker and one fills the queue; the next must wait
+	var submitErr error
+	for i := 0; i < 3 && submitErr == nil; i++ {
+		submitErr = pool.SubmitWithBackpressure(ctx, i, &fs.FileInfo{Path: "test.go"}, resultChan)
+	}
+
+	if submitErr != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", submitErr)
+	}
+}
+
+func TestWorkerPool_TaskTimeout(t *testing.T) {
+	pool, err := NewWorkerPool(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.SetTaskTimeout(20 * time.Millisecond)
+
+	ctx := context.Background()
+	workerFunc := func(ctx context.Context, file *fs.FileInfo) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return nil, nil
+		}
+	}
+	if err := pool.Start(ctx, workerFunc); err != nil {
+		t.Fatal(err)
+	}
+
+	resultChan := make(chan TaskResult, 1)
+	start := time.Now()
+	if err := pool.Submit(ctx, 0, &fs.FileInfo{Path: "slow.go"}, resultChan); err != nil {
+		t.Fatal(err)
+	}
+	result := <-resultChan
+	pool.Stop()
+	pool.Wait()
+
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("task ran for %v despite a 20ms timeout", elapsed)
+	}
+	if result.Error == nil || result.Error.Error() != "review timed out after 20ms" || !result.Retry {
+		t.Errorf("result error = %v, retry = %v; want a retryable 20ms timeout", result.Error, result.Retry)
+	}
+}
+
+func TestWorkerPool_TaskTimeoutPerFile(t *testing.T) {
+	pool, err := NewWorkerPool(2, 2, WithTaskTimeout(func(file *fs.FileInfo) time.Duration {
+		return time.Duration(file.Lines) * time.Millisecond
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	workerFunc := func(ctx context.Context, file *fs.FileInfo) (interface{}, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+			return nil, nil
+		}
+	}
+	if err := pool.Start(ctx, workerFunc); err != nil {
+		t.Fatal(err)
+	}
+
+	resultChan := make(chan TaskResult, 2)
+	if err := pool.Submit(ctx, 0, &fs.FileInfo{Path: "short.go", Lines: 10}, resultChan); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Submit(ctx, 1, &fs.FileInfo{Path: "long.go", Lines: 5000}, resultChan); err != nil {
+		t.Fatal(err)
+	}
+
+	results := make(map[string]TaskResult)
+	for range 2 {
+		result := <-resultChan
+		results[result.File.Path] = result
+	}
+	pool.Stop()
+	pool.Wait()
+
+	// The short file gets 10ms, the long one 5s
+	if err := results["short.go"].Error; err == nil || err.Error() != "review timed out after 10ms" {
+		t.Errorf("short.go error = %v, want a 10ms timeout", err)
+	}
+	if err := results["long.go"].Error; err != nil {
+		t.Errorf("long.go error = %v, want none", err)
+	}
+}
+
+// TestWorkerPool_Resize grows and shrinks the pool while tasks are in flight.
+// Run it with -race to check the worker accounting.
+func TestWorkerPool_Resize(t *testing.T) {
+	const numTasks = 300
+
+	pool, err := NewWorkerPool(2, numTasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Resizing before Start sets how many workers Start launches
+	if err := pool.Resize(4); err != nil {
+		t.Fatalf("Resize before Start failed: %v", err)
+	}
+
+	var running, peak atomic.Int32
+	ctx := context.Background()
+	workerFunc := func(ctx context.Context, file *fs.FileInfo) (interface{}, error) {
+		n := running.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		time.Sleep(500 * time.Microsecond)
+		running.Add(-1)
+		return nil, nil
+	}
+	if err := pool.Start(ctx, workerFunc); err != nil {
+		t.Fatal(err)
+	}
+
+	resultChan := make(chan TaskResult, numTasks)
+	for i := 0; i < numTasks; i++ {
+		if err := pool.Submit(ctx, i, &fs.FileInfo{Path: "test.go"}, resultChan); err != nil {
+			t.Fatalf("failed to submit task %d: %v", i, err)
+		}
+	}
+
+	for _, n := range []int{8, 1, 6, 2, 3} {
+		if err := pool.Resize(n); err != nil {
+			t.Fatalf("Resize(%d) failed: %v", n, err)
+		}
+		if got := pool.Stats()["capacity"]; got != int64(n) {
+			t.Errorf("capacity after Resize(%d) = %d", n, got)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// Shrinking must not drop queued tasks
+	for i := 0; i < numTasks; i++ {
+		select {
+		case <-resultChan:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d of %d results", i, numTasks)
+		}
+	}
+
+	pool.Stop()
+	pool.Wait()
+
+	if peak.Load() < 5 {
+		t.Errorf("peak concurrency %d; growing to 8 workers had no effect", peak.Load())
+	}
+	if active := pool.ActiveWorkers(); active != 0 {
+		t.Errorf("%d workers still active after stop", active)
+	}
+	if err := pool.Resize(2); err != ErrPoolStopped {
+		t.Errorf("Resize after Stop = %v, want ErrPoolStopped", err)
+	}
+	if err := pool.Resize(0); err != ErrInvalidCapacity {
+		t.Errorf("Resize(0) = %v, want ErrInvalidCapacity", err)
+	}
+}
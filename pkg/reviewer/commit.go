@@ -0,0 +1,168 @@
+package reviewer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"scanr/internal/review"
+)
+
+const (
+	maxSubjectLength  = 72
+	maxBodyLineLength = 72
+	commitCategory    = "commit-message"
+	scissorsLine      = "# ------------------------ >8 ------------------------"
+)
+
+var (
+	// conventionalSubject matches "type(scope)!: description"
+	conventionalSubject = regexp.MustCompile(`^([a-z]+)(\([^)]*\))?(!)?: (.*)$`)
+	// looseIssueRef matches footers such as "Fixes 123" that omit the issue marker
+	looseIssueRef = regexp.MustCompile(`(?i)^(fix(es|ed)?|close[sd]?|resolve[sd]?|refs?)[: ]+\d+\b`)
+	// looseBreakingChange matches breaking change footers not written as "BREAKING CHANGE:"
+	looseBreakingChange = regexp.MustCompile(`(?i)^breaking[ -]changes?\s*:`)
+)
+
+// vagueSubjects are subjects that say nothing about the change
+var vagueSubjects = map[string]bool{
+	"wip": true, "fix": true, "fixes": true, "update": true, "updates": true,
+	"changes": true, "misc": true, "stuff": true, "cleanup": true, "tmp": true,
+}
+
+// nonImperativeVerbs are common verb forms that should be written in the imperative
+var nonImperativeVerbs = map[string]string{
+	"adds": "add", "fixes": "fix", "updates": "update", "removes": "remove",
+	"changes": "change", "makes": "make", "implements": "implement", "moves": "move",
+	"renames": "rename", "refactors": "refactor", "improves": "improve", "uses": "use",
+}
+
+// CommitMessageReviewer checks commit messages for subject quality, imperative
+// mood, line length, issue references and breaking change notation
+type CommitMessageReviewer struct{}
+
+// NewCommitMessageReviewer creates a commit message reviewer
+func NewCommitMessageReviewer() *CommitMessageReviewer {
+	return &CommitMessageReviewer{}
+}
+
+// ReviewMessage returns the issues found in a commit message. Lines starting
+// with '#' and everything below the scissors line are ignored, as git does.
+// source is reported as the file path of each issue.
+func (c *CommitMessageReviewer) ReviewMessage(source, message string) []review.Issue {
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+
+	var issues []review.Issue
+	newIssue := func(line int, severity review.Severity, code, title, description string, suggestions ...string) {
+		issues = append(issues, review.Issue{
+			FilePath:    source,
+			Line:        line,
+			Code:        code,
+			Title:       title,
+			Description: description,
+			Severity:    severity,
+			Category:    commitCategory,
+			Suggestions: suggestions,
+			Confidence:  1.0,
+			FoundAt:     time.Now(),
+		})
+	}
+
+	// Find the subject: the first line that is not a comment or blank
+	subjectLine := 0
+	for i, line := range lines {
+		if line == scissorsLine {
+			lines = lines[:i]
+			break
+		}
+	}
+	for i, line := range lines {
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		subjectLine = i + 1
+		break
+	}
+
+	if subjectLine == 0 {
+		newIssue(1, review.SeverityCritical, "COMMIT001", "Empty commit message",
+			"The commit message has no subject line.",
+			"Summarize the change in a short subject line")
+		return issues
+	}
+
+	subject := strings.TrimSpace(lines[subjectLine-1])
+	description := subject
+	if m := conventionalSubject.FindStringSubmatch(subject); m != nil {
+		description = m[4]
+	}
+
+	if len(subject) > maxSubjectLength {
+		newIssue(subjectLine, review.SeverityHigh, "COMMIT002", "Subject line too long",
+			fmt.Sprintf("The subject line is %d characters; keep it within %d.", len(subject), maxSubjectLength),
+			"Move details into the body and keep the subject a short summary")
+	}
+
+	if vagueSubjects[strings.ToLower(strings.TrimRight(description, "."))] || len(description) < 10 {
+		newIssue(subjectLine, review.SeverityHigh, "COMMIT003", "Subject line is not descriptive",
+			fmt.Sprintf("%q does not explain what the commit changes.", subject),
+			"Describe what changed and where, e.g. \"Fix nil check in config loader\"")
+	}
+
+	if fields := strings.Fields(description); len(fields) > 0 {
+		verb := strings.ToLower(fields[0])
+		if imperative, ok := nonImperativeVerbs[verb]; ok {
+			newIssue(subjectLine, review.SeverityHigh, "COMMIT004", "Subject line is not in the imperative mood",
+				fmt.Sprintf("The subject starts with %q.", fields[0]),
+				fmt.Sprintf("Start with %q instead", imperative))
+		} else if strings.HasSuffix(verb, "ed") || strings.HasSuffix(verb, "ing") {
+			newIssue(subjectLine, review.SeverityHigh, "COMMIT004", "Subject line is not in the imperative mood",
+				fmt.Sprintf("The subject starts with %q.", fields[0]),
+				"Write the subject as a command, e.g. \"Add\" rather than \"Added\" or \"Adding\"")
+		}
+	}
+
+	if strings.HasSuffix(subject, ".") {
+		newIssue(subjectLine, review.SeverityInfo, "COMMIT005", "Subject line ends with a period",
+			"Subject lines are titles and should not end with punctuation.",
+			"Remove the trailing period")
+	}
+
+	// The body, if any, is separated from the subject by a blank line
+	if subjectLine < len(lines) {
+		next := lines[subjectLine]
+		if strings.TrimSpace(next) != "" && !strings.HasPrefix(next, "#") {
+			newIssue(subjectLine+1, review.SeverityHigh, "COMMIT006", "Missing blank line after subject",
+				"Git tools treat everything up to the first blank line as the subject.",
+				"Insert a blank line between the subject and the body")
+		}
+	}
+
+	for i := subjectLine; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+
+		if len(line) > maxBodyLineLength && !strings.Contains(line, "://") {
+			newIssue(i+1, review.SeverityInfo, "COMMIT007", "Body line too long",
+				fmt.Sprintf("The line is %d characters; wrap the body at %d.", len(line), maxBodyLineLength))
+		}
+
+		if looseIssueRef.MatchString(trimmed) {
+			newIssue(i+1, review.SeverityInfo, "COMMIT008", "Issue reference without '#'",
+				fmt.Sprintf("%q will not link to the issue.", trimmed),
+				"Reference issues as \"Fixes #123\"")
+		}
+
+		if looseBreakingChange.MatchString(trimmed) && !strings.HasPrefix(trimmed, "BREAKING CHANGE:") {
+			newIssue(i+1, review.SeverityHigh, "COMMIT009", "Breaking change footer is not recognized",
+				"Conventional commits require the footer to be written exactly as \"BREAKING CHANGE:\".",
+				"Write the footer as \"BREAKING CHANGE: <description>\"")
+		}
+	}
+
+	return issues
+}
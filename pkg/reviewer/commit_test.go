@@ -0,0 +1,80 @@
+package reviewer
+
+import (
+	"strings"
+	"testing"
+
+	"scanr/internal/review"
+)
+
+// issueCodes returns the codes of issues in order
+func issueCodes(issues []review.Issue) []string {
+	codes := make([]string, len(issues))
+	for i, issue := range issues {
+		codes[i] = issue.Code
+	}
+	return codes
+}
+
+func TestCommitMessageReviewer_GoodMessage(t *testing.T) {
+	message := "feat(cli): add --output flag for report files\n\n" +
+		"Reports can now be written without shell redirection.\n\n" +
+		"Fixes #42\n" +
+		"# Please enter the commit message for your changes.\n"
+
+	issues := NewCommitMessageReviewer().ReviewMessage("COMMIT_EDITMSG", message)
+	if len(issues) != 0 {
+		t.Errorf("expected no issues, got %v", issueCodes(issues))
+	}
+}
+
+func TestCommitMessageReviewer_Problems(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		code    string
+		line    int
+	}{
+		{"empty", "# only comments\n\n", "COMMIT001", 1},
+		{"long subject", "Add " + strings.Repeat("retry ", 14) + "\n", "COMMIT002", 1},
+		{"vague", "wip\n", "COMMIT003", 1},
+		{"past tense", "Added retry to the worker pool\n", "COMMIT004", 1},
+		{"third person", "fix: fixes nil check in the config loader\n", "COMMIT004", 1},
+		{"trailing period", "Add retry to the worker pool.\n", "COMMIT005", 1},
+		{"no blank line", "Add retry to the worker pool\nRetries failed tasks.\n", "COMMIT006", 2},
+		{"issue reference", "Add retry to the worker pool\n\nFixes 42\n", "COMMIT008", 3},
+		{"breaking change", "feat!: drop the legacy config format\n\nBreaking change: old files are rejected\n", "COMMIT009", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := NewCommitMessageReviewer().ReviewMessage("msg", tt.message)
+
+			found := false
+			for _, issue := range issues {
+				if issue.Code == tt.code {
+					found = true
+					if issue.Line != tt.line {
+						t.Errorf("%s reported on line %d, want %d", tt.code, issue.Line, tt.line)
+					}
+					if issue.FilePath != "msg" || issue.Category != commitCategory {
+						t.Errorf("unexpected issue source: %+v", issue)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("expected %s, got %v", tt.code, issueCodes(issues))
+			}
+		})
+	}
+}
+
+func TestCommitMessageReviewer_IgnoresScissors(t *testing.T) {
+	message := "Add retry to the worker pool\n\n" +
+		"# ------------------------ >8 ------------------------\n" +
+		"diff --git a/pool.go b/pool.go with a very long line that is well past the body line limit\n"
+
+	if issues := NewCommitMessageReviewer().ReviewMessage("msg", message); len(issues) != 0 {
+		t.Errorf("content below the scissors line was reviewed: %v", issueCodes(issues))
+	}
+}
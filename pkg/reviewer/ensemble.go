@@ -0,0 +1,236 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"scanr/internal/fs"
+	"scanr/internal/review"
+)
+
+// EnsembleReviewer runs several reviewers on the same file and merges their findings
+type EnsembleReviewer struct {
+	name           string
+	reviewers      []review.Reviewer
+	lineWindow     int
+	agreementBoost float64
+}
+
+// EnsembleOption configures the ensemble reviewer
+type EnsembleOption func(*EnsembleReviewer)
+
+// WithLineWindow sets how many lines apart two issues may be and still be merged
+func WithLineWindow(lines int) EnsembleOption {
+	return func(er *EnsembleReviewer) {
+		er.lineWindow = lines
+	}
+}
+
+// WithAgreementBoost sets the confidence added for every additional reviewer that agrees
+func WithAgreementBoost(boost float64) EnsembleOption {
+	return func(er *EnsembleReviewer) {
+		er.agreementBoost = boost
+	}
+}
+
+// NewEnsembleReviewer creates a reviewer that fans out to all given reviewers
+func NewEnsembleReviewer(name string, reviewers []review.Reviewer, opts ...EnsembleOption) (*EnsembleReviewer, error) {
+	if len(reviewers) == 0 {
+		return nil, errors.New("ensemble requires at least one reviewer")
+	}
+	for i, r := range reviewers {
+		if r == nil {
+			return nil, fmt.Errorf("reviewer %d is nil", i)
+		}
+	}
+
+	er := &EnsembleReviewer{
+		name:           name,
+		reviewers:      reviewers,
+		lineWindow:     2,
+		agreementBoost: 0.15,
+	}
+
+	for _, opt := range opts {
+		opt(er)
+	}
+
+	return er, nil
+}
+
+// ensembleResult holds the outcome of a single member reviewer
+type ensembleResult struct {
+	index  int
+	issues []review.Issue
+	err    error
+}
+
+// mergedIssue is an issue together with the set of reviewers that reported it
+type mergedIssue struct {
+	issue     review.Issue
+	reporters map[int]bool
+}
+
+// ReviewFile implements the Reviewer interface
+func (e *EnsembleReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
+	results := make([]ensembleResult, len(e.reviewers))
+
+	var wg sync.WaitGroup
+	for i, r := range e.reviewers {
+		wg.Add(1)
+		go func(i int, r review.Reviewer) {
+			defer wg.Done()
+			issues, err := r.ReviewFile(ctx, file)
+			results[i] = ensembleResult{index: i, issues: issues, err: err}
+		}(i, r)
+	}
+	wg.Wait()
+
+	var errs []error
+	var succeeded []ensembleResult
+	for _, res := range results {
+		if res.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.reviewers[res.index].Name(), res.err))
+			continue
+		}
+		succeeded = append(succeeded, res)
+	}
+
+	// Only fail when no member produced a result
+	if len(succeeded) == 0 {
+		return nil, fmt.Errorf("all ensemble reviewers failed: %w", errors.Join(errs...))
+	}
+
+	return e.mergeIssues(succeeded), nil
+}
+
+// Name returns the reviewer name
+func (e *EnsembleReviewer) Name() string {
+	return e.name
+}
+
+// mergeIssues deduplicates near-identical issues and boosts confidence on agreement
+func (e *EnsembleReviewer) mergeIssues(results []ensembleResult) []review.Issue {
+	var merged []*mergedIssue
+
+	for _, res := range results {
+		for _, issue := range res.issues {
+			if existing := e.findMatch(merged, issue); existing != nil {
+				existing.reporters[res.index] = true
+				if severityRank(issue.Severity) > severityRank(existing.issue.Severity) {
+					existing.issue.Severity = issue.Severity
+				}
+				if issue.Confidence > existing.issue.Confidence {
+					existing.issue.Confidence = issue.Confidence
+				}
+				existing.issue.Suggestions = appendUnique(existing.issue.Suggestions, issue.Suggestions...)
+				continue
+			}
+
+			merged = append(merged, &mergedIssue{
+				issue:     issue,
+				reporters: map[int]bool{res.index: true},
+			})
+		}
+	}
+
+	issues := make([]review.Issue, 0, len(merged))
+	for _, m := range merged {
+		issue := m.issue
+		if agreeing := len(m.reporters); agreeing > 1 {
+			issue.Confidence += e.agreementBoost * float64(agreeing-1)
+			if issue.Confidence > 1.0 {
+				issue.Confidence = 1.0
+			}
+		}
+		issues = append(issues, issue)
+	}
+
+	return issues
+}
+
+// findMatch returns the merged issue that the given issue duplicates, if any
+func (e *EnsembleReviewer) findMatch(merged []*mergedIssue, issue review.Issue) *mergedIssue {
+	for _, m := range merged {
+		lineDiff := m.issue.Line - issue.Line
+		if lineDiff < 0 {
+			lineDiff = -lineDiff
+		}
+		if lineDiff > e.lineWindow {
+			continue
+		}
+		if similarTitles(m.issue.Title, issue.Title) {
+			return m
+		}
+	}
+	return nil
+}
+
+// similarTitles reports whether two titles share most of their words
+func similarTitles(a, b string) bool {
+	wordsA := strings.Fields(strings.ToLower(a))
+	wordsB := strings.Fields(strings.ToLower(b))
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return len(wordsA) == len(wordsB)
+	}
+
+	setA := make(map[string]bool, len(wordsA))
+	for _, w := range wordsA {
+		setA[w] = true
+	}
+
+	union := make(map[string]bool, len(wordsA)+len(wordsB))
+	for w := range setA {
+		union[w] = true
+	}
+
+	shared := 0
+	seen := make(map[string]bool, len(wordsB))
+	for _, w := range wordsB {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+		union[w] = true
+		if setA[w] {
+			shared++
+		}
+	}
+
+	// Jaccard similarity of the two word sets
+	return float64(shared)/float64(len(union)) >= 0.5
+}
+
+// severityRank orders severities from least to most severe
+func severityRank(severity review.Severity) int {
+	switch severity {
+	case review.SeverityCritical:
+		return 3
+	case review.SeverityHigh:
+		return 2
+	case review.SeverityInfo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// appendUnique appends values that are not already present
+func appendUnique(slice []string, values ...string) []string {
+	for _, v := range values {
+		found := false
+		for _, existing := range slice {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			slice = append(slice, v)
+		}
+	}
+	return slice
+}
@@ -0,0 +1,135 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"scanr/internal/fs"
+	"scanr/internal/review"
+)
+
+// staticReviewer returns the same issues (or error) for every file
+type staticReviewer struct {
+	name   string
+	issues []review.Issue
+	err    error
+}
+
+func (s *staticReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.issues, nil
+}
+
+func (s *staticReviewer) Name() string {
+	return s.name
+}
+
+func TestNewEnsembleReviewer(t *testing.T) {
+	if _, err := NewEnsembleReviewer("ensemble", nil); err == nil {
+		t.Error("expected error for empty reviewer list")
+	}
+
+	if _, err := NewEnsembleReviewer("ensemble", []review.Reviewer{nil}); err == nil {
+		t.Error("expected error for nil reviewer")
+	}
+}
+
+func TestEnsembleReviewer_MergesAgreeingIssues(t *testing.T) {
+	first := &staticReviewer{
+		name: "first",
+		issues: []review.Issue{
+			{Title: "Unhandled error", Line: 10, Severity: review.SeverityHigh, Confidence: 0.6},
+			{Title: "Magic number", Line: 40, Severity: review.SeverityInfo, Confidence: 0.5},
+		},
+	}
+	second := &staticReviewer{
+		name: "second",
+		issues: []review.Issue{
+			{Title: "Unhandled error returned", Line: 11, Severity: review.SeverityCritical, Confidence: 0.7},
+			{Title: "Resource leak", Line: 80, Severity: review.SeverityCritical, Confidence: 0.8},
+		},
+	}
+
+	ensemble, err := NewEnsembleReviewer("ensemble", []review.Reviewer{first, second},
+		WithLineWindow(2), WithAgreementBoost(0.2))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := ensemble.ReviewFile(context.Background(), &fs.FileInfo{Path: "main.go"})
+	if err != nil {
+		t.Fatalf("ReviewFile failed: %v", err)
+	}
+
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 merged issues, got %d: %+v", len(issues), issues)
+	}
+
+	var merged *review.Issue
+	for i := range issues {
+		if issues[i].Line == 10 {
+			merged = &issues[i]
+		}
+	}
+	if merged == nil {
+		t.Fatal("expected merged issue at line 10")
+	}
+
+	if merged.Severity != review.SeverityCritical {
+		t.Errorf("merged severity = %s, want critical", merged.Severity)
+	}
+	if merged.Confidence < 0.89 || merged.Confidence > 0.91 {
+		t.Errorf("merged confidence = %v, want 0.9", merged.Confidence)
+	}
+}
+
+func TestEnsembleReviewer_PartialFailure(t *testing.T) {
+	ok := &staticReviewer{
+		name:   "ok",
+		issues: []review.Issue{{Title: "Long function", Line: 5, Severity: review.SeverityHigh}},
+	}
+	failing := &staticReviewer{name: "failing", err: errors.New("provider unavailable")}
+
+	ensemble, err := NewEnsembleReviewer("ensemble", []review.Reviewer{ok, failing})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := ensemble.ReviewFile(context.Background(), &fs.FileInfo{Path: "main.go"})
+	if err != nil {
+		t.Fatalf("expected partial success, got error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Errorf("expected 1 issue, got %d", len(issues))
+	}
+
+	allFailing, err := NewEnsembleReviewer("ensemble", []review.Reviewer{failing, failing})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := allFailing.ReviewFile(context.Background(), &fs.FileInfo{Path: "main.go"}); err == nil {
+		t.Error("expected error when every reviewer fails")
+	}
+}
+
+func TestSimilarTitles(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"Unhandled error", "unhandled error", true},
+		{"Unhandled error", "Unhandled error returned", true},
+		{"Unhandled error", "Magic number", false},
+		{"", "", true},
+		{"Resource leak", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := similarTitles(tt.a, tt.b); got != tt.want {
+			t.Errorf("similarTitles(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"scanr/internal/fs"
+	"scanr/internal/review"
+)
+
+// FallbackReviewer tries reviewers in order and moves to the next one when a review fails
+type FallbackReviewer struct {
+	name      string
+	reviewers []review.Reviewer
+	mu        sync.RWMutex
+	backends  map[string]string
+}
+
+// NewFallbackReviewer creates a reviewer chain; the first reviewer is the primary
+func NewFallbackReviewer(name string, reviewers ...review.Reviewer) (*FallbackReviewer, error) {
+	if len(reviewers) == 0 {
+		return nil, errors.New("fallback chain requires at least one reviewer")
+	}
+	for i, r := range reviewers {
+		if r == nil {
+			return nil, fmt.Errorf("reviewer %d is nil", i)
+		}
+	}
+
+	return &FallbackReviewer{
+		name:      name,
+		reviewers: reviewers,
+		backends:  make(map[string]string),
+	}, nil
+}
+
+// ReviewFile implements the Reviewer interface
+func (f *FallbackReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
+	var errs []error
+	var tried []string
+
+	for _, r := range f.reviewers {
+		tried = append(tried, r.Name())
+
+		issues, err := r.ReviewFile(ctx, file)
+		if err == nil {
+			f.mu.Lock()
+			f.backends[file.Path] = r.Name()
+			f.mu.Unlock()
+
+			if len(tried) > 1 {
+				slog.Info("fallback reviewer used",
+					slog.String("file", file.Path),
+					slog.String("path", strings.Join(tried, " -> ")),
+				)
+			}
+			return issues, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", r.Name(), err))
+
+		// A cancelled or expired context fails every backend the same way
+		if ctx.Err() != nil {
+			return nil, errors.Join(errs...)
+		}
+	}
+
+	return nil, fmt.Errorf("all reviewers failed (%s): %w", strings.Join(tried, " -> "), errors.Join(errs...))
+}
+
+// Name returns the reviewer name
+func (f *FallbackReviewer) Name() string {
+	return f.name
+}
+
+// Backend returns the name of the reviewer that produced the issues for a file
+func (f *FallbackReviewer) Backend(path string) (string, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	name, ok := f.backends[path]
+	return name, ok
+}
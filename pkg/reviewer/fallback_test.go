@@ -0,0 +1,97 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"scanr/internal/fs"
+	"scanr/internal/review"
+)
+
+func TestFallbackReviewer_UsesNextOnFailure(t *testing.T) {
+	primary := &staticReviewer{name: "primary", err: errors.New("quota exceeded")}
+	secondary := &staticReviewer{
+		name:   "secondary",
+		issues: []review.Issue{{Title: "Unhandled error", Line: 3}},
+	}
+
+	fallback, err := NewFallbackReviewer("fallback", primary, secondary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := &fs.FileInfo{Path: "/project/main.go"}
+	issues, err := fallback.ReviewFile(context.Background(), file)
+	if err != nil {
+		t.Fatalf("ReviewFile failed: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Errorf("expected 1 issue, got %d", len(issues))
+	}
+
+	backend, ok := fallback.Backend(file.Path)
+	if !ok || backend != "secondary" {
+		t.Errorf("Backend() = %q, %v; want secondary", backend, ok)
+	}
+}
+
+func TestFallbackReviewer_AllFail(t *testing.T) {
+	first := &staticReviewer{name: "first", err: errors.New("unauthorized")}
+	second := &staticReviewer{name: "second", err: errors.New("server error")}
+
+	fallback, err := NewFallbackReviewer("fallback", first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := &fs.FileInfo{Path: "/project/main.go"}
+	if _, err := fallback.ReviewFile(context.Background(), file); err == nil {
+		t.Fatal("expected error when every reviewer fails")
+	}
+
+	if _, ok := fallback.Backend(file.Path); ok {
+		t.Error("no backend should be recorded for a failed file")
+	}
+}
+
+func TestFallbackReviewer_StopsOnCancelledContext(t *testing.T) {
+	calls := 0
+	counting := &countingReviewer{calls: &calls}
+	failing := &staticReviewer{name: "failing", err: context.Canceled}
+
+	fallback, err := NewFallbackReviewer("fallback", failing, counting)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := fallback.ReviewFile(ctx, &fs.FileInfo{Path: "main.go"}); err == nil {
+		t.Fatal("expected error for cancelled context")
+	}
+	if calls != 0 {
+		t.Errorf("secondary reviewer called %d times after cancellation", calls)
+	}
+}
+
+func TestNewFallbackReviewer_Empty(t *testing.T) {
+	if _, err := NewFallbackReviewer("fallback"); err == nil {
+		t.Error("expected error for empty chain")
+	}
+}
+
+// countingReviewer counts how often it is invoked
+type countingReviewer struct {
+	calls *int
+}
+
+func (c *countingReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
+	*c.calls++
+	return nil, nil
+}
+
+func (c *countingReviewer) Name() string {
+	return "counting"
+}
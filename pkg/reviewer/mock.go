@@ -3,7 +3,12 @@ package reviewer
 import (
 	"context"
 	"fmt"
+	"hash/fnv"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"scanr/internal/fs"
@@ -16,7 +21,13 @@ type MockReviewer struct {
 	errorRate     float64 // 0.0 to 1.0
 	avgLatency    time.Duration
 	latencyJitter time.Duration
-	issueRate     float64 // Average issues per file
+	issueRate     float64  // Average issues per file
+	minConfidence float64  // Issues below this confidence are not reported
+	focusAreas    []string // Categories to generate issues for; empty means all
+	seed          int64
+	seeded        bool           // Reseed per file so results do not depend on scheduling
+	fixedIssues   []review.Issue // Returned verbatim instead of generated issues when set
+	mu            sync.Mutex     // Guards rng, which workers share
 	rng           *rand.Rand
 }
 
@@ -63,10 +74,69 @@ func WithIssueRate(rate float64) MockOption {
 	}
 }
 
+// WithMinConfidence drops generated issues scored below min
+func WithMinConfidence(min float64) MockOption {
+	return func(mr *MockReviewer) {
+		mr.minConfidence = min
+	}
+}
+
+// WithFocusAreas restricts generated issues to the given categories
+func WithFocusAreas(areas ...string) MockOption {
+	return func(mr *MockReviewer) {
+		mr.focusAreas = areas
+	}
+}
+
+// WithSeed makes generated latency, errors and issues reproducible. Each file
+// draws from its own sequence derived from the seed and its path, so results
+// are the same however workers interleave.
+func WithSeed(seed int64) MockOption {
+	return func(mr *MockReviewer) {
+		mr.seed = seed
+		mr.seeded = true
+		mr.rng = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithFixedIssues returns the given issues for every file instead of
+// generating random ones, with no simulated latency or errors
+func WithFixedIssues(issues []review.Issue) MockOption {
+	return func(mr *MockReviewer) {
+		mr.fixedIssues = append([]review.Issue{}, issues...)
+	}
+}
+
 // ReviewFile implements the Reviewer interface
 func (m *MockReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]review.Issue, error) {
-	// Simulate latency
+	if m.fixedIssues != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return m.copyFixedIssues(file), nil
+	}
+
+	// Read the source for fix suggestions before taking the lock, so a slow
+	// read does not hold up concurrent reviews
+	source := readSourceLines(file.Path)
+
+	// Draw everything for this file up front so the shared generator is
+	// not held while waiting
+	m.mu.Lock()
+	if m.seeded {
+		hash := fnv.New64a()
+		hash.Write([]byte(file.Path))
+		m.rng.Seed(m.seed ^ int64(hash.Sum64()))
+	}
 	latency := m.avgLatency + time.Duration(m.rng.Int63n(int64(m.latencyJitter)))
+	failed := m.rng.Float64() < m.errorRate
+	var issues []review.Issue
+	if !failed {
+		issues = m.generateIssues(file, source)
+	}
+	m.mu.Unlock()
+
+	// Simulate latency
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -75,11 +145,16 @@ func (m *MockReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]rev
 	}
 
 	// Simulate random errors
-	if m.rng.Float64() < m.errorRate {
+	if failed {
 		return nil, fmt.Errorf("mock review error for %s", file.Path)
 	}
 
-	// Generate mock issues
+	return issues, nil
+}
+
+// generateIssues draws the issues for one file, whose lines are source; the
+// caller holds m.mu
+func (m *MockReviewer) generateIssues(file *fs.FileInfo, source []string) []review.Issue {
 	var issues []review.Issue
 
 	// Determine number of issues for this file
@@ -95,11 +170,29 @@ func (m *MockReviewer) ReviewFile(ctx context.Context, file *fs.FileInfo) ([]rev
 	}
 
 	for i := 0; i < numIssues; i++ {
-		issue := m.generateMockIssue(file)
+		issue, ok := m.generateMockIssue(file, source)
+		if !ok {
+			break
+		}
+		if issue.Confidence < m.minConfidence {
+			continue
+		}
 		issues = append(issues, issue)
 	}
 
-	return issues, nil
+	return issues
+}
+
+// copyFixedIssues returns a copy of the fixed issues attributed to file
+func (m *MockReviewer) copyFixedIssues(file *fs.FileInfo) []review.Issue {
+	issues := make([]review.Issue, len(m.fixedIssues))
+	copy(issues, m.fixedIssues)
+	for i := range issues {
+		if issues[i].FilePath == "" {
+			issues[i].FilePath = file.Path
+		}
+	}
+	return issues
 }
 
 // Name returns the reviewer name
@@ -107,8 +200,9 @@ func (m *MockReviewer) Name() string {
 	return m.name
 }
 
-// generateMockIssue generates a mock issue
-func (m *MockReviewer) generateMockIssue(file *fs.FileInfo) review.Issue {
+// generateMockIssue generates a mock issue; it returns false when no issue
+// type matches the focus areas
+func (m *MockReviewer) generateMockIssue(file *fs.FileInfo, source []string) (review.Issue, bool) {
 	// Common issue patterns
 	issueTypes := []struct {
 		title       string
@@ -166,6 +260,22 @@ func (m *MockReviewer) generateMockIssue(file *fs.FileInfo) review.Issue {
 		},
 	}
 
+	if len(m.focusAreas) > 0 {
+		focused := issueTypes[:0]
+		for _, issueType := range issueTypes {
+			for _, area := range m.focusAreas {
+				if strings.EqualFold(issueType.category, area) {
+					focused = append(focused, issueType)
+					break
+				}
+			}
+		}
+		if len(focused) == 0 {
+			return review.Issue{}, false
+		}
+		issueTypes = focused
+	}
+
 	issueType := issueTypes[m.rng.Intn(len(issueTypes))]
 
 	// Generate random line number (1-100)
@@ -175,18 +285,65 @@ func (m *MockReviewer) generateMockIssue(file *fs.FileInfo) review.Issue {
 	confidence := 0.5 + m.rng.Float64()*0.5
 
 	return review.Issue{
-		FilePath:    file.Path,
-		Line:        line,
-		Column:      m.rng.Intn(80) + 1,
-		Code:        fmt.Sprintf("MOCK%03d", m.rng.Intn(1000)),
-		Title:       issueType.title,
-		Description: issueType.description,
-		Severity:    issueType.severity,
-		Category:    issueType.category,
-		Suggestions: m.generateSuggestions(issueType.category),
-		Confidence:  confidence,
-		FoundAt:     time.Now(),
+		FilePath:      file.Path,
+		Line:          line,
+		Column:        m.rng.Intn(80) + 1,
+		Code:          fmt.Sprintf("MOCK%03d", m.rng.Intn(1000)),
+		Title:         issueType.title,
+		Description:   issueType.description,
+		Severity:      issueType.severity,
+		Category:      issueType.category,
+		Suggestions:   m.generateSuggestions(issueType.category),
+		FixSuggestion: mockFixSuggestion(file, source, line, issueType.title),
+		Confidence:    confidence,
+		FoundAt:       time.Now(),
+	}, true
+}
+
+// readSourceLines returns the lines of the file at path, or nil when it
+// cannot be read
+func readSourceLines(path string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+// mockFixSuggestion builds a unified diff that inserts a TODO comment above
+// line of a file with the given source lines, with 3 lines of context. It
+// returns "" when the file could not be read or is shorter than line.
+func mockFixSuggestion(file *fs.FileInfo, lines []string, line int, title string) string {
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+
+	comment := "//"
+	switch file.Languages {
+	case "python", "ruby", "dockerfile", "compose", "kubernetes", "shell":
+		comment = "#"
+	}
+
+	// Context: up to 3 lines before the insertion and the 3 lines from line on
+	start := max(line-3, 1)
+	end := min(line+2, len(lines))
+
+	path := filepath.ToSlash(file.Relative)
+	if path == "" {
+		path = filepath.Base(file.Path)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+	fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", start, end-start+1, start, end-start+2)
+	for i := start; i <= end; i++ {
+		if i == line {
+			indent := lines[i-1][:len(lines[i-1])-len(strings.TrimLeft(lines[i-1], " \t"))]
+			fmt.Fprintf(&b, "+%s%s TODO: %s\n", indent, comment, strings.ToLower(title))
+		}
+		fmt.Fprintf(&b, " %s\n", lines[i-1])
 	}
+	return b.String()
 }
 
 // generateSuggestions generates mock suggestions based on category
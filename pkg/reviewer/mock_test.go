@@ -0,0 +1,169 @@
+package reviewer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"scanr/internal/fs"
+	"scanr/internal/review"
+)
+
+func TestMockReviewer_MinConfidence(t *testing.T) {
+	mock := NewMockReviewer("mock",
+		WithErrorRate(0),
+		WithLatency(0, time.Microsecond),
+		WithIssueRate(10),
+		WithMinConfidence(0.8),
+	)
+
+	file := &fs.FileInfo{Path: "/project/main.go"}
+	for i := 0; i < 20; i++ {
+		issues, err := mock.ReviewFile(context.Background(), file)
+		if err != nil {
+			t.Fatalf("ReviewFile failed: %v", err)
+		}
+		for _, issue := range issues {
+			if issue.Confidence < 0.8 {
+				t.Fatalf("issue with confidence %v returned despite minimum 0.8", issue.Confidence)
+			}
+		}
+	}
+}
+
+func TestMockReviewer_FocusAreas(t *testing.T) {
+	mock := NewMockReviewer("mock",
+		WithErrorRate(0),
+		WithLatency(0, time.Microsecond),
+		WithIssueRate(10),
+		WithFocusAreas("security"),
+	)
+
+	file := &fs.FileInfo{Path: "/project/main.go"}
+	generated := 0
+	for i := 0; i < 20; i++ {
+		issues, err := mock.ReviewFile(context.Background(), file)
+		if err != nil {
+			t.Fatalf("ReviewFile failed: %v", err)
+		}
+		for _, issue := range issues {
+			if issue.Category != "security" {
+				t.Fatalf("generated %q issue outside focus", issue.Category)
+			}
+		}
+		generated += len(issues)
+	}
+	if generated == 0 {
+		t.Error("expected some security issues")
+	}
+
+	// No issue type matches an unknown focus area
+	unknown := NewMockReviewer("mock", WithErrorRate(0), WithLatency(0, time.Microsecond),
+		WithIssueRate(10), WithFocusAreas("accessibility"))
+	issues, err := unknown.ReviewFile(context.Background(), file)
+	if err != nil || len(issues) != 0 {
+		t.Errorf("expected no issues, got %d (err %v)", len(issues), err)
+	}
+}
+
+func TestMockReviewer_WithSeed(t *testing.T) {
+	newMock := func() *MockReviewer {
+		return NewMockReviewer("mock",
+			WithSeed(42),
+			WithLatency(0, time.Microsecond),
+			WithIssueRate(2),
+		)
+	}
+	first, second := newMock(), newMock()
+
+	files := []*fs.FileInfo{
+		{Path: "/project/a.go"},
+		{Path: "/project/b.go"},
+		{Path: "/project/c.go"},
+	}
+
+	// Review in opposite orders; each file must still get the same result
+	type outcome struct {
+		issues []review.Issue
+		failed bool
+	}
+	run := func(mock *MockReviewer, file *fs.FileInfo) outcome {
+		issues, err := mock.ReviewFile(context.Background(), file)
+		return outcome{issues: issues, failed: err != nil}
+	}
+
+	want := make(map[string]outcome)
+	for _, file := range files {
+		want[file.Path] = run(first, file)
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		file := files[i]
+		got := run(second, file)
+
+		if got.failed != want[file.Path].failed || len(got.issues) != len(want[file.Path].issues) {
+			t.Fatalf("%s: got %d issues (failed %v), want %d (failed %v)", file.Path,
+				len(got.issues), got.failed, len(want[file.Path].issues), want[file.Path].failed)
+		}
+		for j, issue := range want[file.Path].issues {
+			if got.issues[j].Title != issue.Title || got.issues[j].Line != issue.Line {
+				t.Errorf("%s issue %d: got %s at %d, want %s at %d",
+					file.Path, j, got.issues[j].Title, got.issues[j].Line, issue.Title, issue.Line)
+			}
+		}
+	}
+}
+
+func TestMockReviewer_WithFixedIssues(t *testing.T) {
+	fixed := []review.Issue{
+		{Title: "Unhandled error", Line: 3, Severity: review.SeverityCritical},
+		{FilePath: "/elsewhere.go", Title: "Magic number", Line: 8, Severity: review.SeverityInfo},
+	}
+	mock := NewMockReviewer("mock", WithErrorRate(1), WithFixedIssues(fixed))
+
+	issues, err := mock.ReviewFile(context.Background(), &fs.FileInfo{Path: "/project/main.go"})
+	if err != nil {
+		t.Fatalf("fixed issues should bypass simulated errors: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	if issues[0].FilePath != "/project/main.go" || issues[1].FilePath != "/elsewhere.go" {
+		t.Errorf("file paths = %q, %q", issues[0].FilePath, issues[1].FilePath)
+	}
+
+	// The caller's slice is not shared with the results
+	issues[0].Title = "changed"
+	if again, _ := mock.ReviewFile(context.Background(), &fs.FileInfo{Path: "/project/main.go"}); again[0].Title != "Unhandled error" {
+		t.Error("fixed issues were modified through a previous result")
+	}
+}
+
+func TestMockFixSuggestion(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	source := "package main\n\nfunc main() {\n\tf()\n}\n"
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	file := &fs.FileInfo{Path: path, Relative: "main.go", Languages: "go"}
+
+	lines := readSourceLines(path)
+	got := mockFixSuggestion(file, lines, 4, "Unchecked error")
+	want := "--- a/main.go\n+++ b/main.go\n@@ -1,5 +1,6 @@\n package main\n \n func main() {\n+\t// TODO: unchecked error\n \tf()\n }\n"
+	if got != want {
+		t.Errorf("mockFixSuggestion =\n%s\nwant\n%s", got, want)
+	}
+
+	// Lines past the end of the file have nothing to patch
+	if got := mockFixSuggestion(file, lines, 10, "Unchecked error"); got != "" {
+		t.Errorf("expected no fix past the end of the file, got:\n%s", got)
+	}
+
+	// Nor has a file that cannot be read
+	missing := readSourceLines(filepath.Join(dir, "missing.go"))
+	if got := mockFixSuggestion(file, missing, 1, "Unchecked error"); got != "" {
+		t.Errorf("expected no fix for an unreadable file, got:\n%s", got)
+	}
+}
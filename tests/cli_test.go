@@ -52,6 +52,26 @@ func TestCLIFlags(t *testing.T) {
 			args:    []string{"--lang=go", "--max-files=-1"},
 			wantErr: true,
 		},
+		{
+			name:    "invalid max lines",
+			args:    []string{"--lang=go", "--max-lines=0"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid workers",
+			args:    []string{"--lang=go", "--workers=0"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid max file size",
+			args:    []string{"--lang=go", "--max-file-size=big"},
+			wantErr: true,
+		},
+		{
+			name:    "zero default confidence",
+			args:    []string{"--lang=go", "--default-confidence=0"},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -64,6 +84,11 @@ func TestCLIFlags(t *testing.T) {
 			stagedFlag := flag.Bool("staged", true, "")
 			maxFilesFlag := flag.Int("max-files", 100, "")
 			formatFlag := flag.String("format", "text", "")
+			maxFileSizeFlag := flag.String("max-file-size", "1m", "")
+			maxLinesFlag := flag.Int("max-lines", 1000, "")
+			workersFlag := flag.Int("workers", 4, "")
+			queueSizeFlag := flag.Int("queue-size", 100, "")
+			defaultConfidenceFlag := flag.Float64("default-confidence", 0.5, "")
 
 			err := flag.CommandLine.Parse(tt.args)
 			if err != nil {
@@ -74,14 +99,22 @@ func TestCLIFlags(t *testing.T) {
 			}
 
 			// Create config and validate
+			maxFileSize, validateErr := config.ParseByteSize(*maxFileSizeFlag)
 			cfg := &config.Config{
-				Languages:  *langFlag,
-				StagedOnly: *stagedFlag,
-				MaxFiles:   *maxFilesFlag,
-				Format:     strings.ToLower(*formatFlag),
+				Languages:         *langFlag,
+				StagedOnly:        *stagedFlag,
+				MaxFiles:          *maxFilesFlag,
+				MaxFileSize:       maxFileSize,
+				MaxLines:          *maxLinesFlag,
+				Workers:           *workersFlag,
+				QueueSize:         *queueSizeFlag,
+				DefaultConfidence: *defaultConfidenceFlag,
+				Format:            strings.ToLower(*formatFlag),
 			}
 
-			validateErr := config.ValidateConfig(cfg)
+			if validateErr == nil {
+				validateErr = config.ValidateConfig(cfg)
+			}
 
 			if tt.wantErr {
 				if validateErr == nil {
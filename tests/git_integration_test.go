@@ -2,6 +2,7 @@ package tests
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -45,6 +46,7 @@ func TestCLIWithGitIntegration(t *testing.T) {
 		{"staged.py", "def staged_func():\n    pass", true},
 		{"unstaged.py", "def unstaged_func():\n    pass", false},
 		{"ignored.js", "console.log('ignore')", false},
+		{"staged.cs", "class Staged {}", true},
 	}
 
 	for _, tf := range testFiles {
@@ -154,6 +156,42 @@ func TestCLIWithGitIntegration(t *testing.T) {
 			}
 		})
 	}
+
+	// Files taken from git carry the language their extension resolved to
+	t.Run("files have languages", func(t *testing.T) {
+		reportPath := filepath.Join(t.TempDir(), "dry-run.json")
+		cfg := &config.Config{
+			Languages:  "go,python,dotnet",
+			StagedOnly: true,
+			MaxFiles:   10,
+			Format:     "json",
+			DryRun:     true,
+			Output:     reportPath,
+		}
+
+		if _, err := cli.RunReview(context.Background(), cfg); err != nil {
+			t.Fatalf("dry run failed: %v", err)
+		}
+
+		data, err := os.ReadFile(reportPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var report cli.DryRunReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			t.Fatalf("invalid dry run report: %v", err)
+		}
+
+		want := map[string]string{"staged.go": "go", "staged.py": "python", "staged.cs": "dotnet"}
+		if len(report.Files) != len(want) {
+			t.Fatalf("dry run listed %d files, want %d: %+v", len(report.Files), len(want), report.Files)
+		}
+		for _, file := range report.Files {
+			if file.Language != want[file.Path] {
+				t.Errorf("%s has language %q, want %q", file.Path, file.Language, want[file.Path])
+			}
+		}
+	})
 }
 
 func TestCLIWithoutGitRepository(t *testing.T) {
@@ -210,3 +248,147 @@ func TestCLIWithoutGitRepository(t *testing.T) {
 		t.Errorf("unexpected exit code: %d", exitCode)
 	}
 }
+
+func TestCLIWithSince(t *testing.T) {
+	testDir := t.TempDir()
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = testDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(testDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test User")
+
+	write("old.go", "package main\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "Base")
+
+	// Feature branch work, plus a staged file that is not committed
+	run("checkout", "-q", "-b", "feature")
+	write("feature.go", "package main\n\nfunc feature() {}\n")
+	write("feature.py", "def feature():\n    pass\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "Feature")
+	write("staged.go", "package main\n")
+	run("add", "staged.go")
+
+	oldCwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldCwd)
+
+	if err := os.Chdir(testDir); err != nil {
+		t.Fatal(err)
+	}
+
+	reportPath := filepath.Join(t.TempDir(), "dry-run.json")
+	cfg := &config.Config{
+		Languages:  "go",
+		StagedOnly: true,
+		MaxFiles:   10,
+		Format:     "json",
+		DryRun:     true,
+		Output:     reportPath,
+		Since:      "main",
+	}
+
+	if _, err := cli.RunReview(context.Background(), cfg); err != nil {
+		t.Fatalf("RunReview failed: %v", err)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report cli.DryRunReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid dry run report: %v", err)
+	}
+
+	// Only Go files committed on the branch are reviewed
+	if len(report.Files) != 1 || report.Files[0].Path != "feature.go" {
+		t.Errorf("files = %+v, want only feature.go", report.Files)
+	}
+}
+
+func TestCLIWithBareRepository(t *testing.T) {
+	workDir := t.TempDir()
+
+	run := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	run(workDir, "init", "-q", "-b", "main")
+	run(workDir, "config", "user.email", "test@example.com")
+	run(workDir, "config", "user.name", "Test User")
+	for name, content := range map[string]string{
+		"main.go":   "package main\n",
+		"script.py": "def main():\n    pass\n",
+	} {
+		if err := os.WriteFile(filepath.Join(workDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run(workDir, "add", ".")
+	run(workDir, "commit", "-q", "-m", "Initial")
+
+	bareDir := filepath.Join(t.TempDir(), "remote.git")
+	run(workDir, "clone", "-q", "--bare", workDir, bareDir)
+
+	oldCwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldCwd)
+
+	if err := os.Chdir(bareDir); err != nil {
+		t.Fatal(err)
+	}
+
+	reportPath := filepath.Join(t.TempDir(), "dry-run.json")
+	cfg := &config.Config{
+		Languages:  "go",
+		StagedOnly: true,
+		MaxFiles:   10,
+		Format:     "json",
+		DryRun:     true,
+		Output:     reportPath,
+		Branch:     "main",
+	}
+
+	if _, err := cli.RunReview(context.Background(), cfg); err != nil {
+		t.Fatalf("RunReview failed: %v", err)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report cli.DryRunReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("invalid dry run report: %v", err)
+	}
+
+	// Every Go file on the branch is reviewed, without a work tree
+	if len(report.Files) != 1 || report.Files[0].Path != "main.go" {
+		t.Errorf("files = %+v, want only main.go", report.Files)
+	}
+}